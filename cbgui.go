@@ -19,7 +19,7 @@
 package main
 
 import (
-//	"fmt"
+	"fmt"
 	"image"
 	_ "image/png"
 	"math"
@@ -27,6 +27,9 @@ import (
 	"os"
 
 	"github.com/cprevallet/baseballgui/trajectory"
+	"github.com/cprevallet/baseballgui/trajectory/atmos"
+	"github.com/cprevallet/baseballgui/trajectory/optimize"
+	"github.com/cprevallet/baseballgui/world"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
 	"github.com/faiface/pixel/pixelgl"
@@ -40,6 +43,49 @@ type Projectile struct {
         Mat     pixel.Matrix                // linear transformation for movement, rotation, etc.
 }
 
+// Projectile3D is the wind-aware analogue of Projectile: it pairs the
+// sprite/matrix drawn for a world.Body3D with the trajectory World reports
+// back for it, the same role Projectile plays for a world.Body. It is
+// opt-in, toggled with the N key below.
+type Projectile3D struct {
+	Trj  trajectory.TrajectoryPoint3D
+	Wind atmos.WindField
+	Spr  *pixel.Sprite
+	Mat  pixel.Matrix
+}
+
+// initProjectile3D is the wind-aware analogue of initProjectile, for a shot
+// fired into wind. Screen position maps Position[0] (range) plus Position[2]
+// (crosswind) onto the horizontal axis, so a crosswind's sideways deflection
+// shows up as a shift in the shot's horizontal travel.
+func initProjectile3D(
+	initialAltitude float64, // meters
+	initialAngle float64, // degrees from horizontal
+	initialVelocity float64, // m/s
+	spinRate float64, // rad/s, about the axis normal to the trajectory plane
+	wind atmos.WindField,
+	pic pixel.Picture, // sprite image
+) (projectile Projectile3D) {
+	position := [3]float64{0.0, initialAltitude, 0.0}
+	velocity := [3]float64{
+		initialVelocity * math.Cos(initialAngle*math.Pi/180.0),
+		initialVelocity * math.Sin(initialAngle*math.Pi/180.0),
+		0.0,
+	}
+	omega := [3]float64{0.0, 0.0, spinRate}
+	mass := trajectory.Mass()
+	acceleration := trajectory.Accel3D(0.0, position, velocity, omega, wind, mass)
+	trj := trajectory.TrajectoryPoint3D{Time: 0.0, Position: position,
+		Velocity: velocity, Acceleration: acceleration, Omega: omega, Mass: mass}
+
+	sprite := pixel.NewSprite(pic, pic.Bounds())
+	mat := pixel.IM
+	mat = mat.Scaled(pixel.ZV, 0.1)
+	projectile = Projectile3D{trj, wind, sprite, mat}
+
+	return projectile
+}
+
 func loadPicture(path string) (pixel.Picture, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -53,11 +99,14 @@ func loadPicture(path string) (pixel.Picture, error) {
 	return pixel.PictureDataFromImage(img), nil
 }
 
-// InitProjectile provides starting values for a projectile.
+// initProjectile provides starting values for a projectile. spinRate is the
+// projectile's spin (rad/s) about the axis normal to the trajectory plane;
+// pass 0 for a non-spinning shot.
 func initProjectile(
-                initialAltitude float64, // meters 
+                initialAltitude float64, // meters
                 initialAngle float64,    // degrees from horizontal
                 initialVelocity float64, // m/s
+                spinRate float64,       // rad/s, about the axis normal to the trajectory plane
                 pic pixel.Picture,      // sprite image filename
         ) (projectile Projectile) {
 
@@ -65,9 +114,10 @@ func initProjectile(
         position := [2]float64{0.0, initialAltitude}
         velocity := [2]float64{initialVelocity * math.Cos(initialAngle*math.Pi/180.0),
                 initialVelocity * math.Sin(initialAngle*math.Pi/180.0)}
-        acceleration := trajectory.Accel(0.0, position, velocity)
+        mass := trajectory.Mass()
+        acceleration := trajectory.Accel(0.0, position, velocity, spinRate, mass)
         trj := trajectory.TrajectoryPoint{Time: 0.0, Position: position,
-            Velocity: velocity, Acceleration: acceleration}
+            Velocity: velocity, Acceleration: acceleration, Omega: spinRate, Mass: mass}
 
         //  Create the drawable sprite 
         sprite := pixel.NewSprite(pic, pic.Bounds())
@@ -80,20 +130,6 @@ func initProjectile(
         return projectile
 }
 
-// UpdateProjectile computes a trajectory, performing numerical solution of a set of
-// ordinary differential equations with a fixed time step.
-func updateProjectile(prj *Projectile, dt float64) {
-        // Update the matrix to move the sprite on the screen.
-        newTrajectory := trajectory.UpdateRK4(prj.Trj, dt)
-        // What's the change?
-        newVec := pixel.V(newTrajectory.Position[0] - prj.Trj.Position[0],
-                newTrajectory.Position[1] - prj.Trj.Position[1])
-        // Update the moved matrix.
-        prj.Mat = prj.Mat.Moved(newVec)
-        prj.Trj = newTrajectory
-}
-
-
 func run() {
 	// One-time initialization section
 	cfg := pixelgl.WindowConfig{
@@ -114,6 +150,19 @@ func run() {
 	//var Velocity float64 = 35.0 // m/s
 	// this isn't historically accurate, but useful to keep it within the screen resolution
 	var Velocity float64 = 100.0 // m/s
+	var SpinRate float64 = 0.0  // rad/s, rifled spin about the axis normal to the trajectory plane
+	var haveTarget bool = false
+	var TargetX, TargetY float64 // the crosshair planted by a right-click, and auto-aim target
+
+	// Wind is opt-in: the N key toggles it, and left-click then spawns into
+	// World via Spawn3D instead of Spawn, so the crosswind's sideways
+	// deflection is visible without disturbing the default, still-air
+	// flight path. One thing this doesn't cover yet: right-click auto-aim
+	// always solves the still-air 2D firing solution, so it won't
+	// compensate for the crosswind once fired. That would need the firing
+	// solver itself to grow a 3D-aware path.
+	var windEnabled bool = false
+	wind := atmos.LogWind{FrictionVelocity: 0.6, RoughnessLength: 0.03, Direction: [3]float64{0.0, 0.0, 1.0}}
 
 	pic, err := loadPicture("cannonball.png")
 	if err != nil {
@@ -128,24 +177,82 @@ func run() {
 
         last := time.Now() //time of the start of the previous frame
 
-        var inFlight []Projectile
+        // The world owns in-flight projectiles and static targets; it
+        // replaces the ad-hoc inFlight/keepProj slice with continuous
+        // collision detection and impact reporting. inFlight here maps a
+        // world body ID to the sprite/matrix drawn for it.
+        wd := world.NewWorld(trajectory.Mass())
+        // Each frame's dt (scaled by speedFactor below) can span fast
+        // curvature, e.g. near the apex, so step with the adaptive RK45
+        // integrator rather than a single fixed-step RK4 stride; dt itself
+        // stays a display/animation rate, not an integration step size.
+        wd.Integrator = func(p trajectory.TrajectoryPoint, h float64) trajectory.TrajectoryPoint {
+                const atol, rtol = 1e-6, 1e-6
+                history := trajectory.IntegrateAdaptive(p, p.Time+h, atol, rtol)
+                return history[len(history)-1]
+        }
+        inFlight := map[int]*Projectile{}
+
+        // inFlight3D maps a world body ID to the sprite/matrix drawn for a
+        // wind-carrying shot, the same role inFlight plays for still-air
+        // shots.
+        inFlight3D := map[int]*Projectile3D{}
 	for !win.Closed() {
 		dt := time.Since(last).Seconds()
 		last = time.Now()
 		win.Clear(colornames.Blue)
 		imd.Clear()
 
-                // Update the projectile trajectories and draw the sprite.
-                var keepProj []Projectile
-                for i, _ := range inFlight {
-                    updateProjectile(&inFlight[i], dt*speedFactor)
-                    inFlight[i].Spr.Draw(win, inFlight[i].Mat)
-                    if inFlight[i].Trj.Position[1] > 0.0 { keepProj = append(keepProj, inFlight[i]) }
+                // Advance and draw every in-flight projectile; the world
+                // removes bodies that land or hit a target, so prune any
+                // sprite state left behind for a body it no longer owns.
+                wd.Step(dt * speedFactor)
+                live := map[int]bool{}
+                for _, b := range wd.Bodies() {
+                    live[b.ID] = true
+                    prj := inFlight[b.ID]
+                    if prj == nil {
+                        continue
+                    }
+                    newVec := pixel.V(b.Trj.Position[0]-prj.Trj.Position[0],
+                            b.Trj.Position[1]-prj.Trj.Position[1])
+                    prj.Mat = prj.Mat.Moved(newVec)
+                    prj.Trj = b.Trj
+                    prj.Spr.Draw(win, prj.Mat)
+                }
+                for id := range inFlight {
+                    if !live[id] {
+                        delete(inFlight, id)
+                    }
+                }
+
+                // Advance and draw every wind-carrying projectile; the world
+                // removes wind-aware bodies that land or hit a target (see
+                // world.Body3D), so prune any sprite state left behind for
+                // a body it no longer owns, same as the inFlight loop
+                // above. Screen horizontal position tracks
+                // Position[0]+Position[2], so the LogWind crosswind's
+                // sideways push (Position[2]) is visible as a deflection
+                // layered on top of the shot's downrange travel.
+                live3D := map[int]bool{}
+                for _, b := range wd.Bodies3D() {
+                    live3D[b.ID] = true
+                    prj := inFlight3D[b.ID]
+                    if prj == nil {
+                        continue
+                    }
+                    beforeX := prj.Trj.Position[0] + prj.Trj.Position[2]
+                    afterX := b.Trj.Position[0] + b.Trj.Position[2]
+                    newVec := pixel.V(afterX-beforeX, b.Trj.Position[1]-prj.Trj.Position[1])
+                    prj.Mat = prj.Mat.Moved(newVec)
+                    prj.Trj = b.Trj
+                    prj.Spr.Draw(win, prj.Mat)
+                }
+                for id := range inFlight3D {
+                    if !live3D[id] {
+                        delete(inFlight3D, id)
+                    }
                 }
-                // Remove elements that have left the screen.
-                inFlight = nil
-                inFlight = keepProj
-                keepProj = nil
 
 		// Draw a cannon sprite
                 mat := pixel.IM
@@ -153,6 +260,14 @@ func run() {
 		mat = mat.Rotated(pixel.ZV, (Angle-35.0)*math.Pi/180.0)
 		cannon.Draw(win, mat)
 
+		// Draw the targets placed with the T key.
+		imd.Color = colornames.Orange
+		for _, tgt := range wd.Targets() {
+			imd.Push(pixel.V(tgt.Bounds.MinX, tgt.Bounds.MinY))
+			imd.Push(pixel.V(tgt.Bounds.MaxX, tgt.Bounds.MaxY))
+			imd.Rectangle(2.0)
+		}
+
 		// Draw power graph
 		launcherX := 40.0
 		launcherY := 40.0
@@ -162,19 +277,81 @@ func run() {
 		imd.Push(pixel.V(launcherX+offset, launcherY+offset))
 		imd.Push(pixel.V(launcherX+offset+power, launcherY+offset))
 		imd.Line(1.0)
+		// Draw the target crosshair planted by a right-click, if any.
+		if haveTarget {
+			imd.Color = colornames.Yellow
+			crosshairSize := 8.0
+			imd.Push(pixel.V(TargetX-crosshairSize, TargetY))
+			imd.Push(pixel.V(TargetX+crosshairSize, TargetY))
+			imd.Line(2.0)
+			imd.Push(pixel.V(TargetX, TargetY-crosshairSize))
+			imd.Push(pixel.V(TargetX, TargetY+crosshairSize))
+			imd.Line(2.0)
+		}
 		// Draw the trajectory
 		imd.Draw(win)
 		win.Update()
 
 		// Accept keyboard input and calculate a new trajectory.
                 if win.JustPressed(pixelgl.MouseButtonLeft) {
-                    // Initialize our cannonball.
-                    cball := initProjectile(
-                            Altitude,
-                            Angle,
-                            Velocity,
-                            pic)
-                    inFlight = append(inFlight,cball)
+                    if windEnabled {
+                        // Initialize our wind-carrying cannonball and hand it to the world.
+                        cball := initProjectile3D(
+                                Altitude,
+                                Angle,
+                                Velocity,
+                                SpinRate,
+                                wind,
+                                pic)
+                        id := wd.Spawn3D(cball.Trj, cball.Wind)
+                        inFlight3D[id] = &cball
+                    } else {
+                        // Initialize our cannonball and hand it to the world.
+                        cball := initProjectile(
+                                Altitude,
+                                Angle,
+                                Velocity,
+                                SpinRate,
+                                pic)
+                        id := wd.Spawn(cball.Trj)
+                        inFlight[id] = &cball
+                    }
+		}
+
+		// The N key toggles the opt-in crosswind (a LogWind boundary-layer
+		// profile); left-click then spawns wind-carrying shots instead of
+		// still-air ones.
+		if win.JustPressed(pixelgl.KeyN) {
+			windEnabled = !windEnabled
+			fmt.Printf("wind enabled: %v\n", windEnabled)
+		}
+
+		// A right-click plants a target crosshair and auto-aims the
+		// cannon at it using the firing-solution solver.
+		if win.JustPressed(pixelgl.MouseButtonRight) {
+			mouse := win.MousePosition()
+			TargetX, TargetY = mouse.X, mouse.Y
+			haveTarget = true
+			solvedAngle, _, err := trajectory.SolveFiringSolution(
+				Altitude, TargetX, TargetY,
+				trajectory.Options{Velocity: Velocity, Spin: SpinRate})
+			if err == nil {
+				Angle = solvedAngle
+			}
+		}
+
+		// The T key places a hittable target at the mouse position.
+		if win.JustPressed(pixelgl.KeyT) {
+			mouse := win.MousePosition()
+			half := 15.0
+			bounds := world.Bounds{
+				MinX: mouse.X - half, MinY: mouse.Y - half,
+				MaxX: mouse.X + half, MaxY: mouse.Y + half,
+			}
+			wd.AddTarget(bounds, func(impact world.Impact) {
+				fmt.Printf("target hit at (%.1f, %.1f), KE=%.1f J\n",
+					impact.Position[0], impact.Position[1], impact.KineticEnergy)
+			})
 		}
 
 		if win.Pressed(pixelgl.KeyRight) {
@@ -193,6 +370,30 @@ func run() {
 			Angle -= 1.0
 		}
 
+		// Rifle the cannonball to curve its flight via the Magnus effect.
+		if win.Pressed(pixelgl.KeyD) {
+			SpinRate += 1.0
+		}
+
+		if win.Pressed(pixelgl.KeyA) {
+			SpinRate -= 1.0
+		}
+
+		// The M key tunes Angle for maximum range at the current Velocity,
+		// holding Velocity and Mass fixed by pinning their bounds to the
+		// current value.
+		if win.JustPressed(pixelgl.KeyM) {
+			mass := trajectory.Mass()
+			result := optimize.Optimize(Altitude, optimize.MaxRange,
+				optimize.Params{Angle: Angle, Velocity: Velocity, Mass: mass},
+				optimize.Bounds{
+					AngleMin: 0, AngleMax: 89,
+					VelocityMin: Velocity, VelocityMax: Velocity,
+					MassMin: mass, MassMax: mass,
+				}, 0.1, 30)
+			Angle = result.Params.Angle
+		}
+
 	}
 }
 
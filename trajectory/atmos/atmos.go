@@ -0,0 +1,102 @@
+// Package atmos implements the seven-layer 1976 US Standard Atmosphere,
+// valid through 86 km geometric (84.852 km geopotential) altitude, plus a
+// pluggable WindField model. The trajectory package's 3D, wind-aware path
+// uses it; the 2D accel still uses trajectory's own simpleAtmosphere, which
+// only claims accuracy to 20 km and has no concept of wind.
+package atmos
+
+import "math"
+
+// Sea-level reference constants, US Standard Atmosphere 1976.
+const (
+	g0     = 9.80665  // m/s**2, standard gravity
+	rAir   = 287.053  // J/(kg*K), specific gas constant for air
+	rEarth = 6356766.0 // m, effective Earth radius for geopotential conversion
+	t0     = 288.15   // K, sea-level standard temperature
+	p0     = 101325.0 // Pa, sea-level standard pressure
+)
+
+// layer is one of the seven layers of the 1976 model, keyed by geopotential
+// base altitude. lapseRate is 0 for an isothermal layer.
+type layer struct {
+	baseAlt   float64
+	baseTemp  float64
+	basePress float64
+	lapseRate float64
+}
+
+// layers holds the seven layers from the troposphere (0 km) through the
+// upper mesosphere (84.852 km). Base pressure and temperature are derived
+// once, in init, by integrating the lapse rates layer by layer from the
+// sea-level reference.
+var layers []layer
+
+func init() {
+	lapse := []struct {
+		baseAlt, lapseRate float64
+	}{
+		{0, -0.0065},      // Troposphere
+		{11000, 0.0},      // Tropopause
+		{20000, 0.001},    // Stratosphere I
+		{32000, 0.0028},   // Stratosphere II
+		{47000, 0.0},      // Stratopause
+		{51000, -0.0028},  // Mesosphere I
+		{71000, -0.002},   // Mesosphere II
+	}
+	layers = make([]layer, len(lapse))
+	temp, press := t0, p0
+	for i, l := range lapse {
+		layers[i] = layer{baseAlt: l.baseAlt, baseTemp: temp, basePress: press, lapseRate: l.lapseRate}
+		if i+1 < len(lapse) {
+			dh := lapse[i+1].baseAlt - l.baseAlt
+			if l.lapseRate == 0 {
+				press *= math.Exp(-g0 * dh / (rAir * temp))
+			} else {
+				tNext := temp + l.lapseRate*dh
+				press *= math.Pow(tNext/temp, -g0/(l.lapseRate*rAir))
+				temp = tNext
+			}
+		}
+	}
+}
+
+// geopotentialAltitude converts geometric altitude (m) to geopotential
+// altitude (m) using the effective Earth radius.
+func geopotentialAltitude(geometricAlt float64) float64 {
+	return rEarth * geometricAlt / (rEarth + geometricAlt)
+}
+
+// layerFor returns the layer containing geopotential altitude h, clamping
+// to the top layer above 86 km (the model is only "only approximate" there,
+// same as the 20 km caveat it replaces).
+func layerFor(h float64) layer {
+	l := layers[0]
+	for i := len(layers) - 1; i >= 0; i-- {
+		if h >= layers[i].baseAlt {
+			l = layers[i]
+			break
+		}
+	}
+	return l
+}
+
+// Atmosphere computes density, pressure, and temperature ratios relative to
+// sea level (sigma, delta, theta) for geometric altitude altMeters, using
+// the seven-layer 1976 US Standard Atmosphere.
+func Atmosphere(altMeters float64) (sigma, delta, theta float64) {
+	h := geopotentialAltitude(altMeters)
+	l := layerFor(h)
+	dh := h - l.baseAlt
+	var temp, press float64
+	if l.lapseRate == 0 {
+		temp = l.baseTemp
+		press = l.basePress * math.Exp(-g0*dh/(rAir*temp))
+	} else {
+		temp = l.baseTemp + l.lapseRate*dh
+		press = l.basePress * math.Pow(temp/l.baseTemp, -g0/(l.lapseRate*rAir))
+	}
+	theta = temp / t0
+	delta = press / p0
+	sigma = delta / theta
+	return sigma, delta, theta
+}
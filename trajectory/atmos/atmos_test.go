@@ -0,0 +1,34 @@
+package atmos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAtmosphereAtSeaLevel(t *testing.T) {
+	sigma, delta, theta := Atmosphere(0)
+	if math.Abs(sigma-1.0) > 1e-9 || math.Abs(delta-1.0) > 1e-9 || math.Abs(theta-1.0) > 1e-9 {
+		t.Errorf("Atmosphere(0) = (%v, %v, %v), want (1, 1, 1)", sigma, delta, theta)
+	}
+}
+
+func TestAtmosphereAtTropopause(t *testing.T) {
+	// At 11 km the troposphere's constant lapse rate ends; temperature
+	// ratio should match 1976 Standard Atmosphere tables (216.65/288.15).
+	_, _, theta := Atmosphere(11000)
+	want := 216.65 / 288.15
+	if math.Abs(theta-want) > 1e-3 {
+		t.Errorf("theta at 11km = %v, want ~%v", theta, want)
+	}
+}
+
+func TestAtmosphereDensityDecreasesWithAltitude(t *testing.T) {
+	prevSigma := math.Inf(1)
+	for _, alt := range []float64{0, 1000, 5000, 11000, 20000, 32000, 47000, 60000} {
+		sigma, _, _ := Atmosphere(alt)
+		if sigma >= prevSigma {
+			t.Errorf("sigma at %v m = %v, want less than previous %v (density should fall with altitude)", alt, sigma, prevSigma)
+		}
+		prevSigma = sigma
+	}
+}
@@ -0,0 +1,57 @@
+package atmos
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// WindSounding is a wind profile keyed by altitude (m), as replayed from a
+// meteorological sounding, implementing WindField by linear interpolation
+// between the bracketing altitudes.
+type WindSounding struct {
+	Altitudes []float64    `json:"altitudes"` // m, strictly increasing
+	Vectors   [][3]float64 `json:"vectors"`   // m/s, one per altitude
+}
+
+// LoadWindSoundingJSON parses a WindSounding from JSON shaped like
+// {"altitudes": [0, 1000, ...], "vectors": [[vx,vy,vz], ...]}.
+//
+// The request this implements also asked for a YAML loader. This tree has
+// no vendored YAML library and no module manifest to add one, so only the
+// JSON form is implemented; adding a YAML loader is a matter of unmarshaling
+// into the same WindSounding struct once a parser dependency (e.g.
+// gopkg.in/yaml.v2) is available.
+func LoadWindSoundingJSON(data []byte) (WindSounding, error) {
+	var s WindSounding
+	if err := json.Unmarshal(data, &s); err != nil {
+		return WindSounding{}, err
+	}
+	return s, nil
+}
+
+// Wind implements WindField by linearly interpolating between the vectors
+// at the two altitudes bracketing pos[1] (the y-axis is altitude in
+// trajectory's convention), clamping to the nearest vector outside the
+// sounding's range.
+func (s WindSounding) Wind(t float64, pos [3]float64) [3]float64 {
+	if len(s.Altitudes) == 0 {
+		return [3]float64{}
+	}
+	z := pos[1]
+	i := sort.SearchFloat64s(s.Altitudes, z)
+	switch {
+	case i <= 0:
+		return s.Vectors[0]
+	case i >= len(s.Altitudes):
+		return s.Vectors[len(s.Altitudes)-1]
+	default:
+		z0, z1 := s.Altitudes[i-1], s.Altitudes[i]
+		frac := (z - z0) / (z1 - z0)
+		v0, v1 := s.Vectors[i-1], s.Vectors[i]
+		var v [3]float64
+		for k := range v {
+			v[k] = v0[k] + frac*(v1[k]-v0[k])
+		}
+		return v
+	}
+}
@@ -0,0 +1,55 @@
+package atmos
+
+import "testing"
+
+func TestLoadWindSoundingJSON(t *testing.T) {
+	data := []byte(`{"altitudes": [0, 1000, 2000], "vectors": [[1,0,0],[2,0,0],[4,0,0]]}`)
+	s, err := LoadWindSoundingJSON(data)
+	if err != nil {
+		t.Fatalf("LoadWindSoundingJSON: %v", err)
+	}
+	if len(s.Altitudes) != 3 || len(s.Vectors) != 3 {
+		t.Fatalf("got %d altitudes, %d vectors, want 3 each", len(s.Altitudes), len(s.Vectors))
+	}
+}
+
+func TestLoadWindSoundingJSONInvalid(t *testing.T) {
+	if _, err := LoadWindSoundingJSON([]byte("not json")); err == nil {
+		t.Error("expected an error for invalid JSON, got nil")
+	}
+}
+
+func TestWindSoundingInterpolates(t *testing.T) {
+	s := WindSounding{
+		Altitudes: []float64{0, 1000, 2000},
+		Vectors:   [][3]float64{{1, 0, 0}, {3, 0, 0}, {5, 0, 0}},
+	}
+	got := s.Wind(0, [3]float64{0, 500, 0})
+	want := [3]float64{2, 0, 0} // halfway between 1 and 3
+	if got != want {
+		t.Errorf("Wind at 500m = %v, want %v", got, want)
+	}
+}
+
+func TestWindSoundingClampsOutsideRange(t *testing.T) {
+	s := WindSounding{
+		Altitudes: []float64{0, 1000},
+		Vectors:   [][3]float64{{1, 0, 0}, {3, 0, 0}},
+	}
+	below := s.Wind(0, [3]float64{0, -500, 0})
+	if below != s.Vectors[0] {
+		t.Errorf("below-range = %v, want clamped to %v", below, s.Vectors[0])
+	}
+	above := s.Wind(0, [3]float64{0, 5000, 0})
+	if above != s.Vectors[len(s.Vectors)-1] {
+		t.Errorf("above-range = %v, want clamped to %v", above, s.Vectors[len(s.Vectors)-1])
+	}
+}
+
+func TestWindSoundingEmpty(t *testing.T) {
+	var s WindSounding
+	got := s.Wind(0, [3]float64{0, 100, 0})
+	if got != ([3]float64{}) {
+		t.Errorf("empty sounding = %v, want zero", got)
+	}
+}
@@ -0,0 +1,82 @@
+package atmos
+
+import "math"
+
+// WindField returns the wind vector (m/s, x/y/z) at a given time and
+// position, so a caller's drag/Magnus calculation can use the
+// wind-relative velocity v_rel = v - w instead of the ground-relative one.
+type WindField interface {
+	Wind(t float64, pos [3]float64) [3]float64
+}
+
+// ConstantWind is a WindField with the same vector everywhere, for the
+// common case of a steady prevailing wind.
+type ConstantWind struct {
+	Vector [3]float64
+}
+
+// Wind implements WindField.
+func (w ConstantWind) Wind(t float64, pos [3]float64) [3]float64 {
+	return w.Vector
+}
+
+// vonKarman is the von Karman constant used by the logarithmic wind
+// profile below.
+const vonKarman = 0.41
+
+// LogWind is a logarithmic atmospheric boundary-layer WindField,
+// u(z) = (ustar/kappa) * ln(z/z0), directed along Direction (expected to be
+// a horizontal unit vector). Below the roughness length the model is
+// undefined, so Wind returns zero there rather than the singularity at
+// z=0.
+type LogWind struct {
+	FrictionVelocity float64    // ustar, m/s
+	RoughnessLength  float64    // z0, m
+	Direction        [3]float64 // unit vector the wind blows toward
+}
+
+// Wind implements WindField.
+func (w LogWind) Wind(t float64, pos [3]float64) [3]float64 {
+	z := pos[1] // altitude is the y-axis in trajectory's convention
+	if z <= w.RoughnessLength {
+		return [3]float64{0, 0, 0}
+	}
+	speed := (w.FrictionVelocity / vonKarman) * math.Log(z/w.RoughnessLength)
+	return [3]float64{
+		speed * w.Direction[0],
+		speed * w.Direction[1],
+		speed * w.Direction[2],
+	}
+}
+
+// GustWind layers an Ornstein-Uhlenbeck gust process on top of a Base wind
+// field, so a steady wind picks up realistic turbulent fluctuations. The OU
+// state is advanced from a caller-supplied noise source rather than an
+// internal RNG, so a trajectory can be replayed deterministically by
+// reusing the same Noise function.
+type GustWind struct {
+	Base       WindField
+	MeanRevert float64                 // theta, 1/s
+	Volatility float64                 // sigma, m/s/sqrt(s)
+	Noise      func(t float64) float64 // white-noise source, sampled once per distinct t
+
+	state float64
+	lastT float64
+	began bool
+}
+
+// Wind implements WindField. It is stateful: each call after the first
+// advances the gust by however much time passed since the previous call.
+func (w *GustWind) Wind(t float64, pos [3]float64) [3]float64 {
+	if !w.began {
+		w.lastT = t
+		w.began = true
+	}
+	dt := t - w.lastT
+	if dt > 0 {
+		w.state += -w.MeanRevert*w.state*dt + w.Volatility*w.Noise(t)*math.Sqrt(dt)
+		w.lastT = t
+	}
+	base := w.Base.Wind(t, pos)
+	return [3]float64{base[0] + w.state, base[1], base[2]}
+}
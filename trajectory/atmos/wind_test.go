@@ -0,0 +1,69 @@
+package atmos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConstantWind(t *testing.T) {
+	w := ConstantWind{Vector: [3]float64{1, 2, 3}}
+	got := w.Wind(5.0, [3]float64{10, 10, 10})
+	if got != w.Vector {
+		t.Errorf("Wind = %v, want %v", got, w.Vector)
+	}
+}
+
+func TestLogWindBelowRoughnessLengthIsZero(t *testing.T) {
+	w := LogWind{FrictionVelocity: 0.5, RoughnessLength: 1.0, Direction: [3]float64{1, 0, 0}}
+	got := w.Wind(0, [3]float64{0, 0.5, 0})
+	if got != ([3]float64{}) {
+		t.Errorf("Wind below roughness length = %v, want zero", got)
+	}
+}
+
+func TestLogWindIncreasesWithAltitude(t *testing.T) {
+	w := LogWind{FrictionVelocity: 0.5, RoughnessLength: 0.03, Direction: [3]float64{0, 0, 1}}
+	lo := w.Wind(0, [3]float64{0, 10, 0})
+	hi := w.Wind(0, [3]float64{0, 100, 0})
+	loSpeed := math.Abs(lo[2])
+	hiSpeed := math.Abs(hi[2])
+	if hiSpeed <= loSpeed {
+		t.Errorf("speed at 100m (%v) should exceed speed at 10m (%v)", hiSpeed, loSpeed)
+	}
+	if lo[0] != 0 || lo[1] != 0 {
+		t.Errorf("wind should only be directed along z, got %v", lo)
+	}
+}
+
+func TestGustWindFirstCallHasNoOffset(t *testing.T) {
+	base := ConstantWind{Vector: [3]float64{5, 0, 0}}
+	gust := &GustWind{Base: base, MeanRevert: 1.0, Volatility: 1.0, Noise: func(float64) float64 { return 1.0 }}
+	got := gust.Wind(0.0, [3]float64{})
+	if got != base.Vector {
+		t.Errorf("first call should have zero gust state: got %v, want base %v", got, base.Vector)
+	}
+}
+
+func TestGustWindAccumulatesFromNoise(t *testing.T) {
+	base := ConstantWind{Vector: [3]float64{5, 0, 0}}
+	gust := &GustWind{Base: base, MeanRevert: 0.0, Volatility: 1.0, Noise: func(float64) float64 { return 1.0 }}
+	gust.Wind(0.0, [3]float64{})
+	got := gust.Wind(1.0, [3]float64{})
+	if got[0] <= base.Vector[0] {
+		t.Errorf("gust should push x-wind above the base value: got %v, base %v", got[0], base.Vector[0])
+	}
+	if got[1] != 0 || got[2] != 0 {
+		t.Errorf("gust state only perturbs the x component here: got %v", got)
+	}
+}
+
+func TestGustWindRepeatedCallAtSameTimeIsStable(t *testing.T) {
+	base := ConstantWind{Vector: [3]float64{5, 0, 0}}
+	gust := &GustWind{Base: base, MeanRevert: 0.0, Volatility: 1.0, Noise: func(float64) float64 { return 1.0 }}
+	gust.Wind(0.0, [3]float64{})
+	first := gust.Wind(1.0, [3]float64{})
+	second := gust.Wind(1.0, [3]float64{})
+	if first != second {
+		t.Errorf("calling Wind twice at the same t should not advance the gust state: %v != %v", first, second)
+	}
+}
@@ -0,0 +1,136 @@
+// This file implements a firing-solution solver on top of the drag-
+// corrected trajectory model in trajectory.go: given a target point, find
+// the launch angle(s) that make the trajectory intersect it.
+package trajectory
+
+import (
+	"errors"
+	"math"
+)
+
+// Options configures a firing-solution search.
+type Options struct {
+	Velocity float64 // m/s, launch velocity held fixed while solving for angle
+	Spin     float64 // rad/s, spin held constant while searching
+	Dt       float64 // integration time step, seconds; 0 selects a default
+	MaxIter  int     // root-finder iterations; 0 selects a default of 20
+}
+
+// heightAtRange walks a trajectory history and linearly interpolates the
+// height at horizontal distance targetX. ok is false if the trajectory
+// never reaches targetX before falling back to the launch altitude.
+func heightAtRange(history []TrajectoryPoint, targetX float64) (height float64, ok bool) {
+	for i := 1; i < len(history); i++ {
+		x0, x1 := history[i-1].Position[0], history[i].Position[0]
+		if (x0 <= targetX && targetX <= x1) || (x1 <= targetX && targetX <= x0) {
+			if x1 == x0 {
+				return history[i].Position[1], true
+			}
+			fraction := (targetX - x0) / (x1 - x0)
+			y0, y1 := history[i-1].Position[1], history[i].Position[1]
+			return y0 + fraction*(y1-y0), true
+		}
+	}
+	return 0, false
+}
+
+// HeightAtRange exposes heightAtRange for callers (such as the optimize
+// subpackage) that need to evaluate a trajectory's height at a horizontal
+// distance rather than at a point in time.
+func HeightAtRange(history []TrajectoryPoint, targetX float64) (height float64, ok bool) {
+	return heightAtRange(history, targetX)
+}
+
+// missDistance computes the signed vertical miss (height above/below
+// targetY) of a trajectory fired at the given angle (degrees), evaluated at
+// x=targetX. A trajectory that never reaches targetX is reported as an
+// arbitrarily large undershoot so bisection treats it consistently with a
+// too-low angle.
+func missDistance(initialAltitude, targetX, targetY, angle float64, opts Options) float64 {
+	history := trajectoryFrom(initialAltitude, opts.Velocity, angle, opts.Spin, mass, opts.Dt, false)
+	height, ok := heightAtRange(history, targetX)
+	if !ok {
+		return -math.MaxFloat64 / 2
+	}
+	return height - targetY
+}
+
+// AllSolutions returns both the low-angle and high-angle launch angles
+// (degrees, from horizontal) that put a projectile fired at opts.Velocity
+// through the point (targetX, targetY) - the classic two-root ballistic
+// problem. The two roots are bisected on either side of the angle of
+// maximum range, found by a coarse scan. An error is returned if the target
+// is out of range for the requested velocity.
+func AllSolutions(initialAltitude, targetX, targetY float64, opts Options) (low, high float64, err error) {
+	if opts.Dt <= 0 {
+		opts.Dt = 0.1
+	}
+	maxIter := opts.MaxIter
+	if maxIter <= 0 {
+		maxIter = 20
+	}
+
+	// Coarse scan to bracket the angle of maximum range, which separates
+	// the low-angle and high-angle solution families.
+	const scanSteps = 90
+	bestAngle, bestRange := 0.0, -math.MaxFloat64
+	for i := 0; i <= scanSteps; i++ {
+		angle := 89.0 * float64(i) / float64(scanSteps)
+		history := trajectoryFrom(initialAltitude, opts.Velocity, angle, opts.Spin, mass, opts.Dt, false)
+		landing := history[len(history)-1].Position[0]
+		if landing > bestRange {
+			bestRange = landing
+			bestAngle = angle
+		}
+	}
+	if bestRange < targetX {
+		return 0, 0, errors.New("trajectory: target out of range for the requested velocity")
+	}
+
+	bisect := func(lo, hi float64) (float64, error) {
+		flo := missDistance(initialAltitude, targetX, targetY, lo, opts)
+		fhi := missDistance(initialAltitude, targetX, targetY, hi, opts)
+		if flo == 0 {
+			return lo, nil
+		}
+		if fhi == 0 {
+			return hi, nil
+		}
+		if (flo > 0) == (fhi > 0) {
+			return 0, errors.New("trajectory: miss distance does not change sign over the search bracket")
+		}
+		for i := 0; i < maxIter; i++ {
+			mid := 0.5 * (lo + hi)
+			fmid := missDistance(initialAltitude, targetX, targetY, mid, opts)
+			if (fmid > 0) == (flo > 0) {
+				lo, flo = mid, fmid
+			} else {
+				hi, fhi = mid, fmid
+			}
+		}
+		return 0.5 * (lo + hi), nil
+	}
+
+	low, errLow := bisect(0.1, bestAngle)
+	high, errHigh := bisect(bestAngle, 89.9)
+	switch {
+	case errLow != nil && errHigh != nil:
+		return 0, 0, errors.New("trajectory: no firing solution found for the requested target")
+	case errLow != nil:
+		return high, high, nil
+	case errHigh != nil:
+		return low, low, nil
+	}
+	return low, high, nil
+}
+
+// SolveFiringSolution returns a launch angle (degrees) and velocity (m/s)
+// that puts a projectile through the point (targetX, targetY). It prefers
+// the low-angle (flatter, faster-arriving) solution from AllSolutions.
+func SolveFiringSolution(initialAltitude, targetX, targetY float64, opts Options) (angle, velocity float64, err error) {
+	low, _, err := AllSolutions(initialAltitude, targetX, targetY, opts)
+	if err != nil {
+		return 0, 0, err
+	}
+	return low, opts.Velocity, nil
+}
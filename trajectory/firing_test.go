@@ -0,0 +1,89 @@
+package trajectory
+
+import (
+	"math"
+	"testing"
+)
+
+// solutionHitsTarget fires at angle and checks the trajectory passes within
+// tol of (targetX, targetY).
+func solutionHitsTarget(t *testing.T, initialAltitude, targetX, targetY, angle float64, opts Options, tol float64) {
+	t.Helper()
+	history := trajectoryFrom(initialAltitude, opts.Velocity, angle, opts.Spin, mass, opts.Dt, false)
+	height, ok := heightAtRange(history, targetX)
+	if !ok {
+		t.Fatalf("angle %v: trajectory never reaches x=%v", angle, targetX)
+	}
+	if math.Abs(height-targetY) > tol {
+		t.Errorf("angle %v: height at x=%v is %v, want within %v of %v", angle, targetX, height, tol, targetY)
+	}
+}
+
+func TestAllSolutionsReachableTarget(t *testing.T) {
+	opts := Options{Velocity: 60.0, Dt: 0.05}
+
+	// Derive a reachable target from a known trajectory, rather than
+	// guessing coordinates: fire at 20 degrees and take an intermediate
+	// point on its own path as the target.
+	reference := trajectoryFrom(0, opts.Velocity, 20.0, opts.Spin, mass, opts.Dt, false)
+	targetX := reference[len(reference)-1].Position[0] * 0.5
+	targetY, ok := heightAtRange(reference, targetX)
+	if !ok {
+		t.Fatalf("setup: reference trajectory never reaches x=%v", targetX)
+	}
+
+	low, high, err := AllSolutions(0, targetX, targetY, opts)
+	if err != nil {
+		t.Fatalf("AllSolutions: %v", err)
+	}
+	if low > high {
+		t.Errorf("low angle %v should not exceed high angle %v", low, high)
+	}
+
+	const tol = 0.5 // meters
+	solutionHitsTarget(t, 0, targetX, targetY, low, opts, tol)
+	solutionHitsTarget(t, 0, targetX, targetY, high, opts, tol)
+}
+
+func TestAllSolutionsOutOfRange(t *testing.T) {
+	opts := Options{Velocity: 60.0, Dt: 0.05}
+	_, _, err := AllSolutions(0, 1e6, 0, opts)
+	if err == nil {
+		t.Fatal("expected an out-of-range error, got nil")
+	}
+}
+
+func TestAllSolutionsBehindTheCannon(t *testing.T) {
+	opts := Options{Velocity: 60.0, Dt: 0.05}
+	// A target behind the launch point: the trajectory's x only ever
+	// increases from 0, so it can never bracket a negative targetX.
+	_, _, err := AllSolutions(0, -10, 0, opts)
+	if err == nil {
+		t.Fatal("expected an error for a target behind the cannon, got nil")
+	}
+}
+
+func TestSolveFiringSolutionPrefersLowAngle(t *testing.T) {
+	opts := Options{Velocity: 60.0, Dt: 0.05}
+	reference := trajectoryFrom(0, opts.Velocity, 20.0, opts.Spin, mass, opts.Dt, false)
+	targetX := reference[len(reference)-1].Position[0] * 0.5
+	targetY, ok := heightAtRange(reference, targetX)
+	if !ok {
+		t.Fatalf("setup: reference trajectory never reaches x=%v", targetX)
+	}
+
+	low, _, err := AllSolutions(0, targetX, targetY, opts)
+	if err != nil {
+		t.Fatalf("AllSolutions: %v", err)
+	}
+	angle, velocity, err := SolveFiringSolution(0, targetX, targetY, opts)
+	if err != nil {
+		t.Fatalf("SolveFiringSolution: %v", err)
+	}
+	if math.Abs(angle-low) > 1e-9 {
+		t.Errorf("SolveFiringSolution angle = %v, want the low-angle solution %v", angle, low)
+	}
+	if velocity != opts.Velocity {
+		t.Errorf("velocity = %v, want %v", velocity, opts.Velocity)
+	}
+}
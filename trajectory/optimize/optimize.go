@@ -0,0 +1,288 @@
+// Package optimize searches the trajectory package's initial conditions
+// (launch angle, velocity, and projectile mass) for the ones minimizing a
+// caller-supplied cost, subject to box constraints. It is a thin, generic
+// wrapper around trajectory.TrajectoryWithMass: callers compose canned objectives
+// below, or supply their own, and Optimize does the searching.
+package optimize
+
+import (
+	"math"
+
+	"github.com/cprevallet/baseballgui/trajectory"
+)
+
+// Params are the initial conditions being searched over. Mass is passed
+// explicitly to trajectory.TrajectoryWithMass for each evaluation, rather
+// than overriding trajectory package state; pass the same value for
+// MassMin and MassMax to hold it fixed.
+type Params struct {
+	Angle    float64 // degrees from horizontal
+	Velocity float64 // m/s
+	Mass     float64 // kg
+}
+
+// Bounds constrains a search to a box [Min, Max] for each parameter.
+type Bounds struct {
+	AngleMin, AngleMax       float64
+	VelocityMin, VelocityMax float64
+	MassMin, MassMax         float64
+}
+
+// Objective scores a trajectory fired with p from initialAltitude; lower is
+// better.
+type Objective func(initialAltitude float64, p Params, dt float64) (cost float64)
+
+// Step is one iteration's convergence telemetry, so callers can plot the
+// optimizer's trace.
+type Step struct {
+	Iteration    int
+	Cost         float64
+	GradientNorm float64
+}
+
+// Result is the outcome of a search.
+type Result struct {
+	Params Params
+	Cost   float64
+	Trace  []Step
+}
+
+// MaxRange scores by negative landing range, so minimizing it maximizes
+// range.
+func MaxRange(initialAltitude float64, p Params, dt float64) (cost float64) {
+	history := trajectory.TrajectoryWithMass(initialAltitude, p.Velocity, p.Angle, 0.0, p.Mass, dt, false)
+	return -history[len(history)-1].Position[0]
+}
+
+// missCostCap bounds HitTarget's cost so that OptimizeHitTarget's ramped
+// lambda (up to 100) can scale it without overflowing to +Inf - the worst
+// on-target miss saturates at missCostCap and the short-of-target penalty,
+// offset above it, at 2*missCostCap, so both stay large but finite instead
+// of blowing up the cost surface.
+const missCostCap = 1e12
+
+// HitTarget scores by the squared miss distance to (X, Y), evaluated at the
+// target's horizontal range, capped at missCostCap. A trajectory that lands
+// short of X scores missCostCap plus the squared shortfall, capped at
+// 2*missCostCap.
+type HitTarget struct {
+	X, Y float64
+}
+
+// Objective builds the Objective for this target.
+func (h HitTarget) Objective() Objective {
+	return func(initialAltitude float64, p Params, dt float64) (cost float64) {
+		history := trajectory.TrajectoryWithMass(initialAltitude, p.Velocity, p.Angle, 0.0, p.Mass, dt, false)
+		height, ok := trajectory.HeightAtRange(history, h.X)
+		if !ok {
+			// The trajectory lands short of h.X entirely: score by how far
+			// short, offset above the on-target cost range, so a descent
+			// step still has a gradient to climb toward reachability
+			// instead of a flat sentinel it can never climb out of.
+			shortfall := h.X - history[len(history)-1].Position[0]
+			return math.Min(missCostCap+shortfall*shortfall, 2*missCostCap)
+		}
+		miss := height - h.Y
+		return math.Min(miss*miss, missCostCap)
+	}
+}
+
+// MinTimeToAltitude scores by the time to first reach Altitude (meters
+// above the launch altitude, since Trajectory is queried normalized); a
+// trajectory that never reaches it scores an arbitrarily large cost.
+type MinTimeToAltitude struct {
+	Altitude float64
+}
+
+// Objective builds the Objective for this altitude target.
+func (m MinTimeToAltitude) Objective() Objective {
+	return func(initialAltitude float64, p Params, dt float64) (cost float64) {
+		history := trajectory.TrajectoryWithMass(initialAltitude, p.Velocity, p.Angle, 0.0, p.Mass, dt, true)
+		for _, pt := range history {
+			if pt.Position[1] >= m.Altitude {
+				return pt.Time
+			}
+		}
+		return math.MaxFloat64 / 2
+	}
+}
+
+// finite reports whether v is an ordinary, usable float - not NaN or ±Inf.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func clamp(p Params, b Bounds) Params {
+	return Params{
+		Angle:    math.Min(math.Max(p.Angle, b.AngleMin), b.AngleMax),
+		Velocity: math.Min(math.Max(p.Velocity, b.VelocityMin), b.VelocityMax),
+		Mass:     math.Min(math.Max(p.Mass, b.MassMin), b.MassMax),
+	}
+}
+
+// nmExtrapolate returns centroid + coef*(centroid-worst), one point per
+// Params field.
+func nmExtrapolate(centroid, worst Params, coef float64) Params {
+	return Params{
+		Angle:    centroid.Angle + coef*(centroid.Angle-worst.Angle),
+		Velocity: centroid.Velocity + coef*(centroid.Velocity-worst.Velocity),
+		Mass:     centroid.Mass + coef*(centroid.Mass-worst.Mass),
+	}
+}
+
+// nmOrder returns the indices of costs sorted worst, middle, best.
+func nmOrder(costs [3]float64) (worst, mid, best int) {
+	worst, mid, best = 0, 1, 2
+	if costs[worst] < costs[mid] {
+		worst, mid = mid, worst
+	}
+	if costs[mid] < costs[best] {
+		mid, best = best, mid
+	}
+	if costs[worst] < costs[mid] {
+		worst, mid = mid, worst
+	}
+	return worst, mid, best
+}
+
+// nelderMeadStep performs one reflect/expand/contract step of the
+// Nelder-Mead simplex method around center, probing step away along the
+// angle and velocity axes (mass is not probed, so this step never changes
+// Mass), used as a fallback when the finite-
+// difference gradient is unreliable (a flat or discontinuous cost).
+// improved reports whether it found a point beating center's own cost; when
+// it doesn't, Optimize shrinks step and retries rather than calling back
+// with the same unchanged simplex forever.
+func nelderMeadStep(initialAltitude float64, objective Objective, center Params, bounds Bounds, dt float64, step float64) (next Params, improved bool) {
+	centerCost := objective(initialAltitude, center, dt)
+	simplex := [3]Params{
+		center,
+		clamp(Params{Angle: center.Angle + step, Velocity: center.Velocity, Mass: center.Mass}, bounds),
+		clamp(Params{Angle: center.Angle, Velocity: center.Velocity + step, Mass: center.Mass}, bounds),
+	}
+	var costs [3]float64
+	for i, v := range simplex {
+		costs[i] = objective(initialAltitude, v, dt)
+	}
+	worst, mid, best := nmOrder(costs)
+
+	centroid := Params{
+		Angle:    0.5 * (simplex[mid].Angle + simplex[best].Angle),
+		Velocity: 0.5 * (simplex[mid].Velocity + simplex[best].Velocity),
+		Mass:     0.5 * (simplex[mid].Mass + simplex[best].Mass),
+	}
+	reflected := clamp(nmExtrapolate(centroid, simplex[worst], 1.0), bounds)
+	reflectedCost := objective(initialAltitude, reflected, dt)
+
+	candidate, candidateCost := reflected, reflectedCost
+	switch {
+	case reflectedCost < costs[best]:
+		expanded := clamp(nmExtrapolate(centroid, simplex[worst], 2.0), bounds)
+		if c := objective(initialAltitude, expanded, dt); c < reflectedCost {
+			candidate, candidateCost = expanded, c
+		}
+	case reflectedCost >= costs[mid]:
+		contracted := clamp(nmExtrapolate(centroid, simplex[worst], -0.5), bounds)
+		candidate, candidateCost = contracted, objective(initialAltitude, contracted, dt)
+	}
+
+	if candidateCost < centerCost {
+		return candidate, true
+	}
+	return center, false
+}
+
+// Optimize searches within bounds for the Params minimizing objective,
+// starting from initial. Each iteration takes a gradient-descent step using
+// a central finite-difference gradient; when the gradient collapses (the
+// cost surface is locally flat or discontinuous, as HitTarget's can be) it
+// falls back to a Nelder-Mead simplex step instead, shrinking the simplex's
+// probe distance on every step that fails to improve so the search keeps
+// making progress instead of stalling at an unchanged point. Returns the
+// best Params found, its cost, and a convergence trace (iteration, cost,
+// gradient norm).
+func Optimize(initialAltitude float64, objective Objective, initial Params, bounds Bounds, dt float64, maxIter int) (result Result) {
+	const (
+		h            = 1e-3
+		lr           = 0.05
+		gradFloor    = 1e-8
+		nmShrinkStop = 1e-6
+	)
+	p := clamp(initial, bounds)
+	nmStep := 1.0
+	for i := 0; i < maxIter; i++ {
+		cost := objective(initialAltitude, p, dt)
+		gradAngle := (objective(initialAltitude, Params{p.Angle + h, p.Velocity, p.Mass}, dt) -
+			objective(initialAltitude, Params{p.Angle - h, p.Velocity, p.Mass}, dt)) / (2 * h)
+		gradVelocity := (objective(initialAltitude, Params{p.Angle, p.Velocity + h, p.Mass}, dt) -
+			objective(initialAltitude, Params{p.Angle, p.Velocity - h, p.Mass}, dt)) / (2 * h)
+		gradMass := (objective(initialAltitude, Params{p.Angle, p.Velocity, p.Mass + h}, dt) -
+			objective(initialAltitude, Params{p.Angle, p.Velocity, p.Mass - h}, dt)) / (2 * h)
+		gradNorm := math.Sqrt(gradAngle*gradAngle + gradVelocity*gradVelocity + gradMass*gradMass)
+		result.Trace = append(result.Trace, Step{Iteration: i, Cost: cost, GradientNorm: gradNorm})
+
+		// A non-finite cost or gradient (e.g. a penalty that overflowed)
+		// can't be trusted for a descent step - fall back to Nelder-Mead,
+		// which only ever adopts a candidate that beats center on a strict
+		// less-than (NaN/Inf comparisons are never true), so it can't be
+		// poisoned the way a gradient step can.
+		if gradNorm < gradFloor || !finite(cost) || !finite(gradNorm) {
+			if nmStep < nmShrinkStop {
+				break // the simplex has shrunk below any useful resolution
+			}
+			next, improved := nelderMeadStep(initialAltitude, objective, p, bounds, dt, nmStep)
+			if improved {
+				p = next
+				nmStep = 1.0
+			} else {
+				nmStep *= 0.5
+			}
+			continue
+		}
+		next := clamp(Params{
+			Angle:    p.Angle - lr*gradAngle,
+			Velocity: p.Velocity - lr*gradVelocity,
+			Mass:     p.Mass - lr*gradMass,
+		}, bounds)
+		nextCost := objective(initialAltitude, next, dt)
+		if !finite(nextCost) || nextCost > cost {
+			nmNext, improved := nelderMeadStep(initialAltitude, objective, p, bounds, dt, nmStep)
+			if improved {
+				p = nmNext
+				nmStep = 1.0
+			} else {
+				nmStep *= 0.5
+			}
+			continue
+		}
+		p = next
+	}
+	result.Params = p
+	result.Cost = objective(initialAltitude, p, dt)
+	return result
+}
+
+// OptimizeHitTarget finds Params hitting (targetX, targetY), ramping the
+// penalty weight lambda from 1 up to 100 across maxIter outer iterations so
+// early steps make broad progress and later steps converge tightly on the
+// target.
+func OptimizeHitTarget(initialAltitude, targetX, targetY float64, initial Params, bounds Bounds, dt float64, maxIter int) (result Result) {
+	target := HitTarget{X: targetX, Y: targetY}.Objective()
+	rampSteps := maxIter - 1
+	if rampSteps < 1 {
+		rampSteps = 1
+	}
+	p := initial
+	for i := 0; i < maxIter; i++ {
+		lambda := 1.0 + 99.0*float64(i)/float64(rampSteps)
+		penalized := func(initialAltitude float64, p Params, dt float64) float64 {
+			return lambda * target(initialAltitude, p, dt)
+		}
+		inner := Optimize(initialAltitude, penalized, p, bounds, dt, 1)
+		p = inner.Params
+		result.Trace = append(result.Trace, inner.Trace...)
+	}
+	result.Params = p
+	result.Cost = target(initialAltitude, p, dt)
+	return result
+}
@@ -0,0 +1,134 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cprevallet/baseballgui/trajectory"
+)
+
+func TestMaxRangeImprovesOverStartingAngle(t *testing.T) {
+	mass := trajectory.Mass()
+	initial := Params{Angle: 5.0, Velocity: 60.0, Mass: mass}
+	bounds := Bounds{
+		AngleMin: 0, AngleMax: 80,
+		VelocityMin: 60, VelocityMax: 60,
+		MassMin: mass, MassMax: mass,
+	}
+	startCost := MaxRange(0, initial, 0.1)
+
+	result := Optimize(0, MaxRange, initial, bounds, 0.1, 30)
+
+	if result.Cost > startCost {
+		t.Fatalf("optimize made range worse: start cost %v, result cost %v", startCost, result.Cost)
+	}
+	if len(result.Trace) == 0 {
+		t.Fatal("expected a non-empty convergence trace")
+	}
+	if result.Params.Mass != mass {
+		t.Errorf("Mass = %v, want it held fixed at %v by the MassMin==MassMax bound", result.Params.Mass, mass)
+	}
+}
+
+// TestNelderMeadFallbackEscapesFlatPlateau reproduces the stall the review
+// flagged: a cost surface that is flat (zero gradient) everywhere except a
+// narrow notch near the starting point. The old nelderMeadStep probed a
+// fixed step of 1.0 on every call and simply returned the unchanged center
+// forever once that step missed the notch. With the shrinking step added to
+// Optimize's fallback loop, repeated probes at ever-smaller steps eventually
+// land inside the notch.
+func TestNelderMeadFallbackEscapesFlatPlateau(t *testing.T) {
+	const notchLo, notchHi = 0.03, 0.07
+	flatWithNotch := func(_ float64, p Params, _ float64) float64 {
+		if p.Angle >= notchLo && p.Angle <= notchHi {
+			return -1
+		}
+		return 0
+	}
+	initial := Params{Angle: 0, Velocity: 10, Mass: 1}
+	bounds := Bounds{
+		AngleMin: -10, AngleMax: 10,
+		VelocityMin: 10, VelocityMax: 10,
+		MassMin: 1, MassMax: 1,
+	}
+
+	result := Optimize(0, flatWithNotch, initial, bounds, 0.1, 40)
+
+	if result.Cost >= 0 {
+		t.Fatalf("optimizer failed to escape the flat plateau and find the notch: final cost %v, params %+v",
+			result.Cost, result.Params)
+	}
+}
+
+// TestOptimizeHitTargetConvergesOnReachableTarget derives a reachable target
+// from a known trajectory, rather than guessing coordinates, mirroring
+// trajectory's TestAllSolutionsReachableTarget: fire at 20 degrees and take
+// an intermediate point on its own path as the target.
+func TestOptimizeHitTargetConvergesOnReachableTarget(t *testing.T) {
+	mass := trajectory.Mass()
+	const velocity, dt = 60.0, 0.05
+	reference := trajectory.Trajectory(0, velocity, 20.0, dt, false)
+	targetX := reference[len(reference)-1].Position[0] * 0.5
+	targetY, ok := trajectory.HeightAtRange(reference, targetX)
+	if !ok {
+		t.Fatalf("setup: reference trajectory never reaches x=%v", targetX)
+	}
+
+	initial := Params{Angle: 5.0, Velocity: velocity, Mass: mass}
+	bounds := Bounds{
+		AngleMin: 0, AngleMax: 80,
+		VelocityMin: velocity, VelocityMax: velocity,
+		MassMin: mass, MassMax: mass,
+	}
+
+	result := OptimizeHitTarget(0, targetX, targetY, initial, bounds, dt, 30)
+
+	const tol = 1.0 // meters, squared-miss cost tolerance is looser than a direct bisection solver's
+	if result.Cost > tol*tol {
+		t.Fatalf("OptimizeHitTarget squared miss = %v, want at most %v (params %+v)", result.Cost, tol*tol, result.Params)
+	}
+	if len(result.Trace) == 0 {
+		t.Fatal("expected a non-empty convergence trace")
+	}
+}
+
+// TestMinTimeToAltitudePrefersSteeperAngle checks that, starting from a
+// shallow angle that reaches the target altitude, the optimizer does not make
+// the time to get there worse and does not settle on a shallower angle - a
+// shallow angle takes longer to climb to a given height than a steeper one.
+func TestMinTimeToAltitudePrefersSteeperAngle(t *testing.T) {
+	mass := trajectory.Mass()
+	const velocity, dt = 60.0, 0.1
+	objective := MinTimeToAltitude{Altitude: 50.0}.Objective()
+
+	shallow := Params{Angle: 35.0, Velocity: velocity, Mass: mass}
+	startCost := objective(0, shallow, dt)
+	if startCost >= math.MaxFloat64/4 {
+		t.Fatalf("setup: starting angle never reaches the target altitude")
+	}
+
+	bounds := Bounds{
+		AngleMin: 0, AngleMax: 89,
+		VelocityMin: velocity, VelocityMax: velocity,
+		MassMin: mass, MassMax: mass,
+	}
+	result := Optimize(0, objective, shallow, bounds, dt, 30)
+
+	if result.Cost > startCost {
+		t.Fatalf("optimize made time-to-altitude worse: start cost %v, result cost %v", startCost, result.Cost)
+	}
+	if result.Params.Angle < shallow.Angle {
+		t.Errorf("Angle = %v, want no shallower than the starting %v", result.Params.Angle, shallow.Angle)
+	}
+}
+
+func TestClampRespectsMassBounds(t *testing.T) {
+	p := clamp(Params{Angle: 5, Velocity: 10, Mass: 100}, Bounds{
+		AngleMin: 0, AngleMax: 10,
+		VelocityMin: 0, VelocityMax: 20,
+		MassMin: 1, MassMax: 10,
+	})
+	if p.Mass != 10 {
+		t.Errorf("Mass = %v, want clamped to 10", p.Mass)
+	}
+}
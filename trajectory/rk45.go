@@ -0,0 +1,149 @@
+// This file implements an adaptive-step embedded Runge-Kutta-Fehlberg
+// (Cash-Karp) integrator with PI step-size control, as an alternative to
+// the fixed-step baseballKutta for trajectories whose curvature changes
+// quickly (e.g. near the apex, or while crossing a cdSphere Reynolds-number
+// breakpoint).
+package trajectory
+
+import "math"
+
+// Cash-Karp RK45 tableau (Numerical Recipes in C, 2nd ed., section 16.2).
+const (
+	ckA2, ckA3, ckA4, ckA5, ckA6      = 0.2, 0.3, 0.6, 1.0, 0.875
+	ckB21                             = 0.2
+	ckB31, ckB32                      = 3.0 / 40.0, 9.0 / 40.0
+	ckB41, ckB42, ckB43               = 0.3, -0.9, 1.2
+	ckB51, ckB52, ckB53, ckB54        = -11.0 / 54.0, 2.5, -70.0 / 27.0, 35.0 / 27.0
+	ckB61, ckB62, ckB63, ckB64, ckB65 = 1631.0 / 55296.0, 175.0 / 512.0, 575.0 / 13824.0, 44275.0 / 110592.0, 253.0 / 4096.0
+	ckC1, ckC3, ckC4, ckC6            = 37.0 / 378.0, 250.0 / 621.0, 125.0 / 594.0, 512.0 / 1771.0
+	ckDC1                             = ckC1 - 2825.0/27648.0
+	ckDC3                             = ckC3 - 18575.0/48384.0
+	ckDC4                             = ckC4 - 13525.0/55296.0
+	ckDC5                             = -277.0 / 14336.0
+	ckDC6                             = ckC6 - 0.25
+)
+
+// rk45State is the state vector integrated by the adaptive stepper:
+// position and velocity components. Spin carries no derivative (no torque
+// is modeled) so it is threaded through outside this vector, as in
+// baseballKutta.
+type rk45State [4]float64
+
+// rk45Derivative evaluates dy/dt for y=(x, y, vx, vy). projMass is the
+// projectile's mass (kg), constant across the step (see baseballKutta).
+func rk45Derivative(t float64, y rk45State, omega float64, projMass float64) rk45State {
+	pos := [2]float64{y[0], y[1]}
+	vel := [2]float64{y[2], y[3]}
+	a := accel(t, pos, vel, omega, projMass)
+	return rk45State{vel[0], vel[1], a[0], a[1]}
+}
+
+// cashKarpStep advances y by h, returning the 5th-order solution y5 and the
+// embedded error estimate yErr = y5 - y4, component-wise.
+func cashKarpStep(t float64, y rk45State, h float64, omega float64, projMass float64) (y5 rk45State, yErr rk45State) {
+	k1 := rk45Derivative(t, y, omega, projMass)
+
+	var y2 rk45State
+	for i := range y {
+		y2[i] = y[i] + h*ckB21*k1[i]
+	}
+	k2 := rk45Derivative(t+ckA2*h, y2, omega, projMass)
+
+	var y3 rk45State
+	for i := range y {
+		y3[i] = y[i] + h*(ckB31*k1[i]+ckB32*k2[i])
+	}
+	k3 := rk45Derivative(t+ckA3*h, y3, omega, projMass)
+
+	var y4 rk45State
+	for i := range y {
+		y4[i] = y[i] + h*(ckB41*k1[i]+ckB42*k2[i]+ckB43*k3[i])
+	}
+	k4 := rk45Derivative(t+ckA4*h, y4, omega, projMass)
+
+	var y5in rk45State
+	for i := range y {
+		y5in[i] = y[i] + h*(ckB51*k1[i]+ckB52*k2[i]+ckB53*k3[i]+ckB54*k4[i])
+	}
+	k5 := rk45Derivative(t+ckA5*h, y5in, omega, projMass)
+
+	var y6 rk45State
+	for i := range y {
+		y6[i] = y[i] + h*(ckB61*k1[i]+ckB62*k2[i]+ckB63*k3[i]+ckB64*k4[i]+ckB65*k5[i])
+	}
+	k6 := rk45Derivative(t+ckA6*h, y6, omega, projMass)
+
+	for i := range y {
+		y5[i] = y[i] + h*(ckC1*k1[i]+ckC3*k3[i]+ckC4*k4[i]+ckC6*k6[i])
+		yErr[i] = h * (ckDC1*k1[i] + ckDC3*k3[i] + ckDC4*k4[i] + ckDC5*k5[i] + ckDC6*k6[i])
+	}
+	return y5, yErr
+}
+
+// IntegrateAdaptive integrates p0 forward to tEnd using the embedded
+// Cash-Karp Runge-Kutta-Fehlberg stepper above, with a PI-style step-size
+// controller: the per-component error norm ||y5-y4||/(atol+rtol*||y||)
+// drives h, and a step whose norm exceeds 1 is rejected and retried with a
+// smaller h. Returns the full variable-step history. Unlike Trajectory,
+// this does not stop at the initial altitude - it always integrates to
+// tEnd. A GUI loop's frame dt serves only as the span to advance
+// (tEnd = p0.Time + dt), never as the integration step size, which the
+// controller chooses.
+func IntegrateAdaptive(p0 TrajectoryPoint, tEnd float64, atol float64, rtol float64) (history []TrajectoryPoint) {
+	const (
+		safety = 0.9
+		facMax = 5.0
+		facMin = 0.2
+		hMin   = 1e-6
+	)
+	history = append(history, p0)
+	t := p0.Time
+	if tEnd <= t {
+		return history
+	}
+	omega := p0.Omega
+	projMass := p0.Mass
+	y := rk45State{p0.Position[0], p0.Position[1], p0.Velocity[0], p0.Velocity[1]}
+	h := math.Min(tEnd-t, 0.1)
+
+	for t < tEnd {
+		if t+h > tEnd {
+			h = tEnd - t
+		}
+		y5, yErr := cashKarpStep(t, y, h, omega, projMass)
+
+		errNorm := 0.0
+		for i := range y {
+			scale := atol + rtol*math.Max(math.Abs(y[i]), math.Abs(y5[i]))
+			if scale == 0 {
+				scale = atol
+			}
+			e := yErr[i] / scale
+			errNorm += e * e
+		}
+		errNorm = math.Sqrt(errNorm / float64(len(y)))
+
+		accepted := errNorm <= 1.0 || h <= hMin
+		if accepted {
+			t += h
+			y = y5
+			pos := [2]float64{y[0], y[1]}
+			vel := [2]float64{y[2], y[3]}
+			history = append(history, TrajectoryPoint{
+				Time: t, Position: pos, Velocity: vel,
+				Acceleration: accel(t, pos, vel, omega, projMass), Omega: omega, Mass: projMass,
+			})
+		}
+
+		fac := facMax
+		if errNorm > 0 {
+			fac = safety * math.Pow(errNorm, -0.2)
+		}
+		fac = math.Min(facMax, math.Max(facMin, fac))
+		h *= fac
+		if h < hMin {
+			h = hMin
+		}
+	}
+	return history
+}
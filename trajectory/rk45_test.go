@@ -0,0 +1,63 @@
+package trajectory
+
+import (
+	"math"
+	"testing"
+)
+
+// specificEnergy returns the kinetic plus potential energy per unit mass
+// (J/kg) of a trajectory point.
+func specificEnergy(p TrajectoryPoint) float64 {
+	vsq := p.Velocity[0]*p.Velocity[0] + p.Velocity[1]*p.Velocity[1]
+	return 0.5*vsq + g*p.Position[1]
+}
+
+// maxEnergyDrift returns the largest deviation in specific energy from the
+// first point in history.
+func maxEnergyDrift(history []TrajectoryPoint) float64 {
+	e0 := specificEnergy(history[0])
+	maxDrift := 0.0
+	for _, p := range history {
+		d := math.Abs(specificEnergy(p) - e0)
+		if d > maxDrift {
+			maxDrift = d
+		}
+	}
+	return maxDrift
+}
+
+// TestEnergyDriftRK45VsRK4 compares the energy drift of the adaptive RK45
+// stepper against the fixed-step RK4 stepper over a 30-second flight. Drag
+// dissipates energy over the flight, so this isn't a conservation check;
+// it's a regression guard that the error-controlled stepper tracks the
+// trajectory at least as faithfully as the fixed step near the apex, where
+// velocity direction changes quickly.
+func TestEnergyDriftRK45VsRK4(t *testing.T) {
+	initialAltitude := 100.0
+	initialVelocity := 60.0
+	initialTheta := 45.0
+	dt := 0.1
+	tEnd := 30.0
+
+	position := [2]float64{0.0, initialAltitude}
+	velocity := [2]float64{initialVelocity * math.Cos(initialTheta*math.Pi/180.0),
+		initialVelocity * math.Sin(initialTheta*math.Pi/180.0)}
+	p0 := TrajectoryPoint{Time: 0.0, Position: position, Velocity: velocity,
+		Acceleration: accel(0.0, position, velocity, 0.0, mass), Mass: mass}
+
+	fixed := []TrajectoryPoint{p0}
+	for fixed[len(fixed)-1].Time < tEnd {
+		fixed = append(fixed, UpdateRK4(fixed[len(fixed)-1], dt))
+	}
+	adaptive := IntegrateAdaptive(p0, tEnd, 1e-6, 1e-6)
+
+	fixedDrift := maxEnergyDrift(fixed)
+	adaptiveDrift := maxEnergyDrift(adaptive)
+
+	// Allow a small margin: both methods track the same dissipative
+	// trajectory, so their drift should be comparable rather than
+	// bit-for-bit ordered.
+	if adaptiveDrift > fixedDrift*1.05 {
+		t.Errorf("RK45 energy drift %.6g exceeds fixed-step RK4 drift %.6g by more than 5%%", adaptiveDrift, fixedDrift)
+	}
+}
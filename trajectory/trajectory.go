@@ -12,6 +12,28 @@ type TrajectoryPoint struct {
 	Position     [2]float64
 	Velocity     [2]float64
 	Acceleration [2]float64
+	// Omega is the spin rate about the axis normal to the trajectory
+	// plane, rad/s. The trajectory lives entirely in the (x, y) = (range,
+	// altitude) plane, so there is no left/right axis to curve into;
+	// instead, the Magnus force deflects the ball perpendicular to its
+	// velocity within that same plane, i.e. lifting or dropping it off
+	// the spin-free path depending on the sign of Omega. No torque is
+	// modeled, so Omega is carried forward unchanged for the life of the
+	// trajectory.
+	//
+	// There is deliberately no orientation field (quaternion or 2D
+	// rotation angle) alongside Omega: with no torque and no caller that
+	// reads orientation, integrating one here would just be a second
+	// unused rad*dt accumulator carried through baseballKutta for no
+	// behavioral effect. Add it if a caller needs the projectile's
+	// attitude (e.g. rendering a tumbling sprite, or a torque model).
+	Omega float64
+	// Mass is the projectile's mass (kg), used by accel alongside drag and
+	// Magnus lift. Like Omega, it is carried forward unchanged for the
+	// life of the trajectory rather than read from package state, so a
+	// caller (e.g. trajectory/optimize) can vary it per trajectory
+	// without mutating anything shared.
+	Mass float64
 }
 
 // Package conversion factor constants:
@@ -35,15 +57,49 @@ var sref = 0.25 * math.Pi * diam * diam         // frontal area (sq.m)
 var diam =  4.95 / 12 * ft2meters               // diameter of a cannonball (m)
 var mass = 5.4                                  // mass of a cannonball (kg)
 var sref = 0.25 * math.Pi * diam * diam         // frontal area (sq.m)
+var radius = diam / 2.0                         // radius of the projectile (m)
+
+// Mass returns the default projectile mass (kg) used by Trajectory and
+// TrajectoryWithSpin, so callers computing kinetic energy elsewhere (e.g. a
+// collision system) don't need to duplicate the constant. Callers that want
+// a different mass pass it explicitly to TrajectoryWithMass rather than
+// changing this default.
+func Mass() float64 {
+	return mass
+}
+
+// spinParameter computes the dimensionless spin parameter S = r*|omega|/|v|
+// of a spinning sphere, used to estimate its Magnus lift coefficient.
+func spinParameter(omega float64, vmag float64) (S float64) {
+	if vmag <= 0 {
+		return 0
+	}
+	return radius * math.Abs(omega) / vmag
+}
+
+// magnusLift estimates the lift coefficient Cl(S) of a smooth spinning
+// sphere from its spin parameter S. Cl -> 0 as S -> 0 (a non-spinning
+// ball generates no Magnus force).
+func magnusLift(S float64) (cl float64) {
+	if S <= 0 {
+		return 0
+	}
+	return 1.0 / (2.0 + 1.0/S)
+}
 
 // accel computes the acceleration (vector) for a spherical projectile
 // moving through a viscous medium. Assume Mach number is small enough
-// that wave drag may be neglected. Ignore added mass term.
+// that wave drag may be neglected. Ignore added mass term. omega is the
+// projectile's spin rate (rad/s) about the axis normal to the trajectory
+// plane and adds a Magnus lift term alongside drag and gravity. projMass is
+// the projectile's mass (kg), an explicit argument rather than the package
+// mass constant so a caller (e.g. trajectory/optimize) can vary it per
+// trajectory.
 // NOTE - position has units of meters, but first argument to simpleAtmosphere
 //   is in kilometers. Be sure to remember to multiply by 0.001
-func accel(time float64, position [2]float64, velocity [2]float64) (acceleration [2]float64) {
+func accel(time float64, position [2]float64, velocity [2]float64, omega float64, projMass float64) (acceleration [2]float64) {
 	vertical := [2]float64{0.0, 1.0}
-	var drag, unitVelocity [2]float64
+	var drag, magnus, unitVelocity [2]float64
 	vsq := 0.0
 	for _, v := range velocity {
 		vsq += math.Pow(v, 2.0)
@@ -61,9 +117,19 @@ func accel(time float64, position [2]float64, velocity [2]float64) (acceleration
 	reynolds := density * vmag * diam / viscosity(theta)
 	cd := cdSphere(reynolds)
 	dragMagnitude := cd * q * sref
+	// Magnus lift acts perpendicular to velocity, in the direction of
+	// omega (out of plane) crossed with the unit velocity.
+	cl := magnusLift(spinParameter(omega, vmag))
+	magnusMagnitude := 0.5 * density * cl * sref * vmag * math.Abs(omega)
+	magnusDir := [2]float64{-unitVelocity[1], unitVelocity[0]}
+	magnusSign := 1.0
+	if omega < 0 {
+		magnusSign = -1.0
+	}
 	for i, _ := range acceleration {
 		drag[i] = -dragMagnitude * unitVelocity[i]
-		acceleration[i] = drag[i]/mass - g*vertical[i]
+		magnus[i] = magnusSign * magnusMagnitude * magnusDir[i]
+		acceleration[i] = (drag[i]+magnus[i])/projMass - g*vertical[i]
 	}
 	return acceleration
 }
@@ -150,11 +216,16 @@ func baseballKutta(p1 TrajectoryPoint, h float64) (p2 TrajectoryPoint) {
 	var dx1, dx2, dx3, dx4 [2]float64
 	var dv1, dv2, dv3, dv4 [2]float64
 
+	// No torque is modeled, so spin is constant across the step; mass is
+	// likewise constant across the step (no ablation or fuel burn modeled).
+	omega := p1.Omega
+	projMass := p1.Mass
+
 	//start of interval
 	t := p1.Time
 	x := p1.Position
 	v := p1.Velocity
-	a := accel(t, x, v)
+	a := accel(t, x, v, omega, projMass)
 	for i := 0; i < 2; i++ {
 		dx1[i] = h * v[i]
 		dv1[i] = h * a[i]
@@ -166,7 +237,7 @@ func baseballKutta(p1 TrajectoryPoint, h float64) (p2 TrajectoryPoint) {
 		x2[i] = x[i] + dx1[i]/2.0
 		v2[i] = v[i] + dv1[i]/2.0
 	}
-	a = accel(t+h/2.0, x2, v2)
+	a = accel(t+h/2.0, x2, v2, omega, projMass)
 	for i := 0; i < 2; i++ {
 		dx2[i] = h * (v[i] + dv1[i]/2.0)
 		dv2[i] = h * a[i]
@@ -178,7 +249,7 @@ func baseballKutta(p1 TrajectoryPoint, h float64) (p2 TrajectoryPoint) {
 		x3[i] = x[i] + dx2[i]/2.0
 		v3[i] = v[i] + dv2[i]/2.0
 	}
-	a = accel(t+h/2.0, x3, v3)
+	a = accel(t+h/2.0, x3, v3, omega, projMass)
 	for i := 0; i < 2; i++ {
 		dx3[i] = h * (v[i] + dv2[i]/2.0)
 		dv3[i] = h * a[i]
@@ -190,7 +261,7 @@ func baseballKutta(p1 TrajectoryPoint, h float64) (p2 TrajectoryPoint) {
 		x4[i] = x[i] + dx3[i]
 		v4[i] = v[i] + dv3[i]
 	}
-	a = accel(t+h, x4, v4)
+	a = accel(t+h, x4, v4, omega, projMass)
 	for i := 0; i < 2; i++ {
 		dx4[i] = h * (v[i] + dv3[i])
 		dv4[i] = h * a[i]
@@ -201,27 +272,46 @@ func baseballKutta(p1 TrajectoryPoint, h float64) (p2 TrajectoryPoint) {
 		p2.Position[i] = p1.Position[i] + (dx1[i]+dx2[i]+dx2[i]+dx3[i]+dx3[i]+dx4[i])/6.0
 		p2.Velocity[i] = p1.Velocity[i] + (dv1[i]+dv2[i]+dv2[i]+dv3[i]+dv3[i]+dv4[i])/6.0
 	}
-	p2.Acceleration = accel(p2.Time, p2.Position, p2.Velocity)
+	p2.Omega = omega
+	p2.Mass = projMass
+	p2.Acceleration = accel(p2.Time, p2.Position, p2.Velocity, omega, projMass)
 	return
 }
 
-// Trajectory computes a trajectory, performing numerical solution of a set of
-// ordinary differential equations with a fixed time step. Halt the
+// Accel exposes accel for callers that integrate a trajectory
+// incrementally (e.g. a real-time GUI loop) rather than all at once via
+// Trajectory. spinRate is rad/s about the axis normal to the trajectory
+// plane; pass 0 for a non-spinning projectile. projMass is the projectile's
+// mass (kg); pass Mass() for the package's default projectile.
+func Accel(time float64, position [2]float64, velocity [2]float64, spinRate float64, projMass float64) (acceleration [2]float64) {
+	return accel(time, position, velocity, spinRate, projMass)
+}
+
+// UpdateRK4 advances a TrajectoryPoint by one fixed time step h using the
+// same fourth-order Runge-Kutta integrator as Trajectory.
+func UpdateRK4(p1 TrajectoryPoint, h float64) (p2 TrajectoryPoint) {
+	return baseballKutta(p1, h)
+}
+
+// trajectoryFrom computes a trajectory, performing numerical solution of a
+// set of ordinary differential equations with a fixed time step. Halt the
 // calculation when the altitude is less than the initial altitude and
 // correct the final point to have the same altitude as the initial altitude.
 // initialAltitude = meters
 // initialTheta    = degrees from horizontal
 // initialVelocity = m/s
+// spinRate        = rad/s, about the axis normal to the trajectory plane
+// projMass        = kg, projectile mass
 // normalized      = make the output positions relative ref: initalAltitude = 0
-func Trajectory(initialAltitude float64, initialVelocity float64, initialTheta float64, dt float64, normalized bool) (history []TrajectoryPoint) {
+func trajectoryFrom(initialAltitude float64, initialVelocity float64, initialTheta float64, spinRate float64, projMass float64, dt float64, normalized bool) (history []TrajectoryPoint) {
         // Initialize vectors.
 	t := 0.0
 	position := [2]float64{0.0, initialAltitude}
 	velocity := [2]float64{initialVelocity * math.Cos(initialTheta*math.Pi/180.0),
 		initialVelocity * math.Sin(initialTheta*math.Pi/180.0)}
-	acceleration := accel(t, position, velocity)
+	acceleration := accel(t, position, velocity, spinRate, projMass)
 	initialTrajectory := TrajectoryPoint{Time: t, Position: position,
-            Velocity: velocity, Acceleration: acceleration}
+            Velocity: velocity, Acceleration: acceleration, Omega: spinRate, Mass: projMass}
 	history = append(history, initialTrajectory)
         // Perform the Runge-Kutta.
 	k := 0
@@ -245,3 +335,28 @@ func Trajectory(initialAltitude float64, initialVelocity float64, initialTheta f
 	}
 	return history
 }
+
+// Trajectory computes a non-spinning trajectory for the package's default
+// projectile mass (see Mass). See trajectoryFrom for parameter details.
+func Trajectory(initialAltitude float64, initialVelocity float64, initialTheta float64, dt float64, normalized bool) (history []TrajectoryPoint) {
+	return trajectoryFrom(initialAltitude, initialVelocity, initialTheta, 0.0, mass, dt, normalized)
+}
+
+// TrajectoryWithSpin computes a trajectory, at the package's default
+// projectile mass (see Mass), for a projectile launched with spinRate
+// (rad/s) about the axis normal to the trajectory plane, so the resulting
+// path curves due to the Magnus effect - e.g. a curveball, or a rifled
+// cannonball hooking off-target. See trajectoryFrom for the remaining
+// parameter details.
+func TrajectoryWithSpin(initialAltitude float64, initialVelocity float64, initialTheta float64, spinRate float64, dt float64, normalized bool) (history []TrajectoryPoint) {
+	return trajectoryFrom(initialAltitude, initialVelocity, initialTheta, spinRate, mass, dt, normalized)
+}
+
+// TrajectoryWithMass computes a trajectory the same way as TrajectoryWithSpin,
+// but with an explicit projectile mass (kg) rather than the package default -
+// e.g. trajectory/optimize searching mass as a box-constrained parameter
+// alongside angle and velocity. See trajectoryFrom for the remaining
+// parameter details.
+func TrajectoryWithMass(initialAltitude float64, initialVelocity float64, initialTheta float64, spinRate float64, projMass float64, dt float64, normalized bool) (history []TrajectoryPoint) {
+	return trajectoryFrom(initialAltitude, initialVelocity, initialTheta, spinRate, projMass, dt, normalized)
+}
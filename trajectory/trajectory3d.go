@@ -0,0 +1,216 @@
+// This file adds a full 3D, wind-aware trajectory alongside the 2D
+// Trajectory/TrajectoryWithSpin above. TrajectoryPoint's 2D plane is
+// load-bearing for SolveFiringSolution, the optimize subpackage, and
+// IntegrateAdaptive, so rather than widening it in place (and breaking all
+// three), 3D flight with crosswind deflection is exposed as a parallel
+// TrajectoryPoint3D type that opts into the atmos package's WindField
+// model. It reuses trajectory.go's drag and Magnus-lift building blocks
+// (cdSphere, viscosity, magnusLift, spinParameter, sref, diam, rhozero, g).
+package trajectory
+
+import (
+	"math"
+
+	"github.com/cprevallet/baseballgui/trajectory/atmos"
+)
+
+// TrajectoryPoint3D is the 3D analogue of TrajectoryPoint: x is range, y is
+// altitude (as in TrajectoryPoint), and z is the crosswind/lateral axis.
+type TrajectoryPoint3D struct {
+	Time         float64
+	Position     [3]float64
+	Velocity     [3]float64
+	Acceleration [3]float64
+	// Omega is the spin vector (rad/s) about an arbitrary axis. No torque
+	// is modeled, so it is carried forward unchanged for the life of the
+	// trajectory.
+	Omega [3]float64
+	// Mass is the projectile's mass (kg), threaded through explicitly the
+	// same way as TrajectoryPoint.Mass: carried forward unchanged for the
+	// life of the trajectory rather than read from package state.
+	Mass float64
+}
+
+// accel3D is the 3D, wind-aware analogue of accel: drag, Reynolds number,
+// and Magnus lift are all computed from the wind-relative velocity
+// v_rel = v - w rather than the ground-relative velocity. wind may be nil
+// for still air. projMass is the projectile's mass (kg), passed explicitly
+// rather than read from package state, mirroring accel.
+func accel3D(time float64, position [3]float64, velocity [3]float64, omega [3]float64, wind atmos.WindField, projMass float64) (acceleration [3]float64) {
+	var w [3]float64
+	if wind != nil {
+		w = wind.Wind(time, position)
+	}
+	var vRel [3]float64
+	for i := range vRel {
+		vRel[i] = velocity[i] - w[i]
+	}
+	vsq := vRel[0]*vRel[0] + vRel[1]*vRel[1] + vRel[2]*vRel[2]
+	vmag := math.Sqrt(vsq)
+	var unitVRel [3]float64
+	if vmag > 0 {
+		for i := range unitVRel {
+			unitVRel[i] = vRel[i] / vmag
+		}
+	}
+
+	sigma, _, theta := atmos.Atmosphere(position[1])
+	density := sigma * rhozero
+	q := 0.5 * density * vsq
+	reynolds := density * vmag * diam / viscosity(theta)
+	cd := cdSphere(reynolds)
+	dragMagnitude := cd * q * sref
+
+	// omega x unitVRel sets the Magnus direction; Cl(S), density, and
+	// |v_rel| set its strength. S is evaluated from |omega| as in the 2D
+	// case, since spinParameter only cares about spin magnitude.
+	cross := [3]float64{
+		omega[1]*unitVRel[2] - omega[2]*unitVRel[1],
+		omega[2]*unitVRel[0] - omega[0]*unitVRel[2],
+		omega[0]*unitVRel[1] - omega[1]*unitVRel[0],
+	}
+	omegaMag := math.Sqrt(omega[0]*omega[0] + omega[1]*omega[1] + omega[2]*omega[2])
+	cl := magnusLift(spinParameter(omegaMag, vmag))
+	magnusMagnitude := 0.5 * density * cl * sref * vmag
+
+	vertical := [3]float64{0, 1, 0}
+	for i := range acceleration {
+		drag := -dragMagnitude * unitVRel[i]
+		magnus := magnusMagnitude * cross[i]
+		acceleration[i] = (drag+magnus)/projMass - g*vertical[i]
+	}
+	return acceleration
+}
+
+// Accel3D exposes accel3D for callers that integrate a 3D trajectory
+// incrementally (e.g. a real-time GUI loop) rather than all at once via
+// Trajectory3D. wind may be nil for still air.
+func Accel3D(time float64, position [3]float64, velocity [3]float64, omega [3]float64, wind atmos.WindField, projMass float64) (acceleration [3]float64) {
+	return accel3D(time, position, velocity, omega, wind, projMass)
+}
+
+// UpdateRK43D advances a TrajectoryPoint3D by one fixed time step h using
+// the same fourth-order Runge-Kutta integrator as Trajectory3D.
+func UpdateRK43D(p1 TrajectoryPoint3D, h float64, wind atmos.WindField) (p2 TrajectoryPoint3D) {
+	return kutta3D(p1, h, wind)
+}
+
+// kutta3D advances one fixed time step of a TrajectoryPoint3D, mirroring
+// baseballKutta's fourth-order Runge-Kutta over 3 components and a wind
+// field.
+func kutta3D(p1 TrajectoryPoint3D, h float64, wind atmos.WindField) (p2 TrajectoryPoint3D) {
+	var dx1, dx2, dx3, dx4 [3]float64
+	var dv1, dv2, dv3, dv4 [3]float64
+
+	// No torque is modeled, so spin is constant across the step.
+	omega := p1.Omega
+	projMass := p1.Mass
+
+	t := p1.Time
+	x := p1.Position
+	v := p1.Velocity
+	a := accel3D(t, x, v, omega, wind, projMass)
+	for i := 0; i < 3; i++ {
+		dx1[i] = h * v[i]
+		dv1[i] = h * a[i]
+	}
+
+	var x2, v2 [3]float64
+	for i := 0; i < 3; i++ {
+		x2[i] = x[i] + dx1[i]/2.0
+		v2[i] = v[i] + dv1[i]/2.0
+	}
+	a = accel3D(t+h/2.0, x2, v2, omega, wind, projMass)
+	for i := 0; i < 3; i++ {
+		dx2[i] = h * (v[i] + dv1[i]/2.0)
+		dv2[i] = h * a[i]
+	}
+
+	var x3, v3 [3]float64
+	for i := 0; i < 3; i++ {
+		x3[i] = x[i] + dx2[i]/2.0
+		v3[i] = v[i] + dv2[i]/2.0
+	}
+	a = accel3D(t+h/2.0, x3, v3, omega, wind, projMass)
+	for i := 0; i < 3; i++ {
+		dx3[i] = h * (v[i] + dv2[i]/2.0)
+		dv3[i] = h * a[i]
+	}
+
+	var x4, v4 [3]float64
+	for i := 0; i < 3; i++ {
+		x4[i] = x[i] + dx3[i]
+		v4[i] = v[i] + dv3[i]
+	}
+	a = accel3D(t+h, x4, v4, omega, wind, projMass)
+	for i := 0; i < 3; i++ {
+		dx4[i] = h * (v[i] + dv3[i])
+		dv4[i] = h * a[i]
+	}
+
+	p2.Time = t + h
+	for i := 0; i < 3; i++ {
+		p2.Position[i] = p1.Position[i] + (dx1[i]+dx2[i]+dx2[i]+dx3[i]+dx3[i]+dx4[i])/6.0
+		p2.Velocity[i] = p1.Velocity[i] + (dv1[i]+dv2[i]+dv2[i]+dv3[i]+dv3[i]+dv4[i])/6.0
+	}
+	p2.Omega = omega
+	p2.Mass = projMass
+	p2.Acceleration = accel3D(p2.Time, p2.Position, p2.Velocity, omega, wind, projMass)
+	return
+}
+
+// correctFinalPosition3D mirrors correctFinalPosition over 3 components.
+func correctFinalPosition3D(initialAltitude float64, a1 TrajectoryPoint3D, a2 TrajectoryPoint3D) (corrected TrajectoryPoint3D) {
+	fraction := (initialAltitude - a1.Position[1]) / (a2.Position[1] - a1.Position[1])
+	corrected.Time = a1.Time + fraction*(a2.Time-a1.Time)
+	for i := 0; i < 3; i++ {
+		corrected.Position[i] = a1.Position[i] + fraction*(a2.Position[i]-a1.Position[i])
+		corrected.Velocity[i] = a1.Velocity[i] + fraction*(a2.Velocity[i]-a1.Velocity[i])
+		corrected.Acceleration[i] = a1.Acceleration[i] + fraction*(a2.Acceleration[i]-a1.Acceleration[i])
+	}
+	corrected.Omega = a1.Omega
+	corrected.Mass = a1.Mass
+	return
+}
+
+// Trajectory3D computes a 3D, wind-aware trajectory: drag and Magnus lift
+// act on the wind-relative velocity (gravity acts alone), so a crosswind
+// (or a logarithmic boundary layer, or a gust) deflects the flight path
+// sideways as well as perturbing its range. wind may be nil for still air.
+// initialTheta is degrees from horizontal in the initial x-y plane; the
+// shot starts with no initial crosswind (z) velocity. projMass is the
+// projectile's mass (kg), threaded explicitly the same way as
+// trajectoryFrom's mass parameter. Other parameters are as described for
+// Trajectory.
+func Trajectory3D(initialAltitude float64, initialVelocity float64, initialTheta float64, spinRate [3]float64, wind atmos.WindField, projMass float64, dt float64, normalized bool) (history []TrajectoryPoint3D) {
+	t := 0.0
+	position := [3]float64{0.0, initialAltitude, 0.0}
+	velocity := [3]float64{
+		initialVelocity * math.Cos(initialTheta*math.Pi/180.0),
+		initialVelocity * math.Sin(initialTheta*math.Pi/180.0),
+		0.0,
+	}
+	acceleration := accel3D(t, position, velocity, spinRate, wind, projMass)
+	initialTrajectory := TrajectoryPoint3D{Time: t, Position: position,
+		Velocity: velocity, Acceleration: acceleration, Omega: spinRate, Mass: projMass}
+	history = append(history, initialTrajectory)
+
+	k := 0
+	cond := true
+	for ok := true; ok; ok = cond {
+		newTrajectory := kutta3D(history[k], dt, wind)
+		k++
+		history = append(history, newTrajectory)
+		cond = (newTrajectory.Position[1] > initialAltitude)
+	}
+	corrected := correctFinalPosition3D(initialAltitude,
+		history[len(history)-2], history[len(history)-1])
+	history = history[:len(history)-1]
+	history = append(history, corrected)
+	if normalized {
+		for i := len(history) - 1; i > -1; i-- {
+			history[i].Position[1] = history[i].Position[1] - history[0].Position[1]
+		}
+	}
+	return history
+}
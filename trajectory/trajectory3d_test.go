@@ -0,0 +1,25 @@
+package trajectory
+
+import (
+	"testing"
+
+	"github.com/cprevallet/baseballgui/trajectory/atmos"
+)
+
+func TestTrajectory3DStillAirStaysInPlane(t *testing.T) {
+	history := Trajectory3D(0, 50, 30, [3]float64{}, nil, mass, 0.01, false)
+	for _, p := range history {
+		if p.Position[2] != 0 {
+			t.Fatalf("still air should not produce crosswind drift, got z=%v", p.Position[2])
+		}
+	}
+}
+
+func TestTrajectory3DCrosswindDeflects(t *testing.T) {
+	wind := atmos.ConstantWind{Vector: [3]float64{0, 0, 10}}
+	history := Trajectory3D(0, 50, 30, [3]float64{}, wind, mass, 0.01, false)
+	final := history[len(history)-1]
+	if final.Position[2] <= 0 {
+		t.Errorf("a crosswind along +z should deflect the shot to positive z, got %v", final.Position[2])
+	}
+}
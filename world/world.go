@@ -0,0 +1,283 @@
+// Package world owns in-flight projectiles (both still-air Bodies and
+// wind-aware Body3Ds) and static targets, advancing them each step and
+// detecting collisions between them via continuous collision detection, so
+// a fast projectile (at a high speedFactor) can't tunnel through a small
+// target between steps.
+package world
+
+import (
+	"math"
+
+	"github.com/cprevallet/baseballgui/trajectory"
+	"github.com/cprevallet/baseballgui/trajectory/atmos"
+)
+
+// Bounds is an axis-aligned bounding box, in the same 2D plane as
+// trajectory.TrajectoryPoint.
+type Bounds struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Target is a static obstacle or target. OnHit, if non-nil, is called
+// synchronously from Step when a Body's swept path intersects it.
+type Target struct {
+	ID     int
+	Bounds Bounds
+	OnHit  func(Impact)
+}
+
+// Body is a projectile in flight, identified by an ID so callers can
+// correlate it with their own rendering state (e.g. a sprite and matrix).
+type Body struct {
+	ID  int
+	Trj trajectory.TrajectoryPoint
+}
+
+// Body3D is the wind-aware analogue of Body: a projectile in flight that
+// drifts in 3D (TrajectoryPoint3D.Position[2] is the crosswind axis). Wind
+// is carried alongside the trajectory so Step can advance it with
+// trajectory.UpdateRK43D.
+type Body3D struct {
+	ID   int
+	Trj  trajectory.TrajectoryPoint3D
+	Wind atmos.WindField
+}
+
+// Impact records a collision between a Body and a Target: time, position,
+// velocity, and kinetic energy at the moment of impact.
+type Impact struct {
+	TargetID      int
+	BodyID        int
+	Time          float64
+	Position      [2]float64
+	Velocity      [2]float64
+	KineticEnergy float64 // Joules, 0.5*mass*speed^2; a Body3D's uses its full 3D speed, not the projected Velocity
+}
+
+// World owns in-flight bodies and static targets.
+type World struct {
+	// Mass is the projectile mass (kg) used to compute Impact.KineticEnergy.
+	Mass float64
+
+	// Integrator advances a Body's TrajectoryPoint by dt. It defaults to
+	// trajectory.UpdateRK4 (a single fixed-step RK4 stride); a caller whose
+	// frame dt can span fast curvature (e.g. near the apex, or after a
+	// speedFactor-scaled dt) can instead set this to a wrapper around an
+	// adaptive stepper such as trajectory.IntegrateAdaptive, keeping dt itself a pure
+	// display/animation rate rather than an integration step size.
+	Integrator func(trajectory.TrajectoryPoint, float64) trajectory.TrajectoryPoint
+
+	bodies   []Body
+	bodies3D []Body3D
+	targets  []Target
+	nextID   int
+
+	// Impacts receives every Impact as it happens, for scoring or
+	// telemetry, in addition to any Target.OnHit callback. Sends are
+	// non-blocking: a full channel drops the impact rather than stalling
+	// Step.
+	Impacts chan Impact
+}
+
+// NewWorld creates an empty World. mass is the projectile mass (kg); pass
+// trajectory.Mass() to match the trajectory package's own projectile.
+func NewWorld(mass float64) *World {
+	return &World{Mass: mass, Integrator: trajectory.UpdateRK4, Impacts: make(chan Impact, 16)}
+}
+
+// AddTarget registers a target and returns its ID.
+func (w *World) AddTarget(bounds Bounds, onHit func(Impact)) int {
+	w.nextID++
+	id := w.nextID
+	w.targets = append(w.targets, Target{ID: id, Bounds: bounds, OnHit: onHit})
+	return id
+}
+
+// Targets returns the registered targets, for callers that need to draw
+// them.
+func (w *World) Targets() []Target {
+	return w.targets
+}
+
+// Spawn adds a new in-flight body at trj and returns its ID.
+func (w *World) Spawn(trj trajectory.TrajectoryPoint) int {
+	w.nextID++
+	id := w.nextID
+	w.bodies = append(w.bodies, Body{ID: id, Trj: trj})
+	return id
+}
+
+// Bodies returns the currently in-flight bodies, for callers that need to
+// draw them. It reflects the state as of the most recent Step.
+func (w *World) Bodies() []Body {
+	return w.bodies
+}
+
+// Spawn3D adds a new in-flight wind-aware body at trj, advected by wind,
+// and returns its ID. Step checks it against Targets the same way as a
+// Body: by projecting its 3D position onto the 2D plane Targets are placed
+// in (downrange + crosswind, altitude), matching how a wind-carrying shot
+// is drawn on screen (see cbgui.go).
+func (w *World) Spawn3D(trj trajectory.TrajectoryPoint3D, wind atmos.WindField) int {
+	w.nextID++
+	id := w.nextID
+	w.bodies3D = append(w.bodies3D, Body3D{ID: id, Trj: trj, Wind: wind})
+	return id
+}
+
+// Bodies3D returns the currently in-flight wind-aware bodies, for callers
+// that need to draw them. It reflects the state as of the most recent Step.
+func (w *World) Bodies3D() []Body3D {
+	return w.bodies3D
+}
+
+// project3D collapses a TrajectoryPoint3D position onto the 2D plane
+// Targets live in: downrange travel plus crosswind drift on the horizontal
+// axis, altitude on the vertical axis.
+func project3D(position [3]float64) [2]float64 {
+	return [2]float64{position[0] + position[2], position[1]}
+}
+
+// Step advances every in-flight body by dt using w.Integrator, then checks
+// each body's swept segment between its old and new position against every
+// target's Bounds. A body that hits a target is removed and reported as an
+// Impact (to the target's OnHit and to w.Impacts); a body that falls below
+// y=0 without a hit is removed silently, same as the "remove when y<0"
+// check this replaces. Wind-aware bodies (see Spawn3D) are advanced by one
+// trajectory.UpdateRK43D step each, not by w.Integrator, and swept the same
+// way, projected onto the same 2D plane (see project3D).
+func (w *World) Step(dt float64) {
+	var kept []Body
+	for _, b := range w.bodies {
+		before := b.Trj
+		after := w.Integrator(before, dt)
+
+		hitID, hitT, hitPos := w.sweepHit(before.Position, after.Position)
+		if hitID != 0 {
+			impactVel := [2]float64{
+				before.Velocity[0] + hitT*(after.Velocity[0]-before.Velocity[0]),
+				before.Velocity[1] + hitT*(after.Velocity[1]-before.Velocity[1]),
+			}
+			speedSq := impactVel[0]*impactVel[0] + impactVel[1]*impactVel[1]
+			impact := Impact{
+				TargetID:      hitID,
+				BodyID:        b.ID,
+				Time:          before.Time + hitT*dt,
+				Position:      hitPos,
+				Velocity:      impactVel,
+				KineticEnergy: 0.5 * w.Mass * speedSq,
+			}
+			w.report(hitID, impact)
+			continue // the body is consumed by the hit
+		}
+
+		b.Trj = after
+		if after.Position[1] > 0.0 {
+			kept = append(kept, b)
+		}
+	}
+	w.bodies = kept
+
+	var kept3D []Body3D
+	for _, b := range w.bodies3D {
+		before := b.Trj
+		after := trajectory.UpdateRK43D(before, dt, b.Wind)
+
+		beforePos, afterPos := project3D(before.Position), project3D(after.Position)
+		hitID, hitT, hitPos := w.sweepHit(beforePos, afterPos)
+		if hitID != 0 {
+			impactVel3 := [3]float64{
+				before.Velocity[0] + hitT*(after.Velocity[0]-before.Velocity[0]),
+				before.Velocity[1] + hitT*(after.Velocity[1]-before.Velocity[1]),
+				before.Velocity[2] + hitT*(after.Velocity[2]-before.Velocity[2]),
+			}
+			speedSq := impactVel3[0]*impactVel3[0] + impactVel3[1]*impactVel3[1] + impactVel3[2]*impactVel3[2]
+			impact := Impact{
+				TargetID:      hitID,
+				BodyID:        b.ID,
+				Time:          before.Time + hitT*dt,
+				Position:      hitPos,
+				Velocity:      [2]float64{impactVel3[0] + impactVel3[2], impactVel3[1]},
+				KineticEnergy: 0.5 * w.Mass * speedSq,
+			}
+			w.report(hitID, impact)
+			continue
+		}
+
+		b.Trj = after
+		if after.Position[1] > 0.0 {
+			kept3D = append(kept3D, b)
+		}
+	}
+	w.bodies3D = kept3D
+}
+
+// report delivers impact to its target's OnHit callback and to w.Impacts.
+func (w *World) report(targetID int, impact Impact) {
+	for _, t := range w.targets {
+		if t.ID == targetID && t.OnHit != nil {
+			t.OnHit(impact)
+		}
+	}
+	select {
+	case w.Impacts <- impact:
+	default:
+	}
+}
+
+// sweepHit finds the earliest target whose Bounds intersect the segment
+// from p0 to p1, returning its ID, the parametric fraction t in [0,1] along
+// the segment, and the hit position. A zero ID means no hit.
+func (w *World) sweepHit(p0, p1 [2]float64) (targetID int, t float64, pos [2]float64) {
+	d := [2]float64{p1[0] - p0[0], p1[1] - p0[1]}
+	bestT := math.Inf(1)
+	bestID := 0
+	var bestPos [2]float64
+	for _, target := range w.targets {
+		tEnter, ok := segmentAABBEntry(p0, d, target.Bounds)
+		if !ok {
+			continue
+		}
+		if tEnter < bestT {
+			bestT = tEnter
+			bestID = target.ID
+			bestPos = [2]float64{p0[0] + tEnter*d[0], p0[1] + tEnter*d[1]}
+		}
+	}
+	if bestID == 0 {
+		return 0, 0, [2]float64{}
+	}
+	return bestID, bestT, bestPos
+}
+
+// segmentAABBEntry solves the parametric segment p0+t*d, t in [0,1],
+// against an axis-aligned box using the slab method, returning the entry
+// parameter (clamped to 0 if the segment starts inside the box).
+func segmentAABBEntry(p0, d [2]float64, b Bounds) (tEnter float64, ok bool) {
+	tEnter, tExit := 0.0, 1.0
+	mins := [2]float64{b.MinX, b.MinY}
+	maxs := [2]float64{b.MaxX, b.MaxY}
+	for i := 0; i < 2; i++ {
+		if d[i] == 0 {
+			if p0[i] < mins[i] || p0[i] > maxs[i] {
+				return 0, false
+			}
+			continue
+		}
+		t0 := (mins[i] - p0[i]) / d[i]
+		t1 := (maxs[i] - p0[i]) / d[i]
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0 > tEnter {
+			tEnter = t0
+		}
+		if t1 < tExit {
+			tExit = t1
+		}
+		if tEnter > tExit {
+			return 0, false
+		}
+	}
+	return tEnter, true
+}
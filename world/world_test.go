@@ -0,0 +1,136 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cprevallet/baseballgui/trajectory"
+)
+
+func TestSegmentAABBEntry(t *testing.T) {
+	box := Bounds{MinX: 10, MinY: 10, MaxX: 20, MaxY: 20}
+
+	cases := []struct {
+		name       string
+		p0, d      [2]float64
+		wantOK     bool
+		wantTEnter float64
+	}{
+		{
+			name:       "segment starts inside the box",
+			p0:         [2]float64{15, 15},
+			d:          [2]float64{5, 0},
+			wantOK:     true,
+			wantTEnter: 0,
+		},
+		{
+			name:       "fast segment tunnels through the box in one step",
+			p0:         [2]float64{0, 15},
+			d:          [2]float64{30, 0}, // crosses the whole box within a single large step
+			wantOK:     true,
+			wantTEnter: 10.0 / 30.0,
+		},
+		{
+			name:   "segment parallel to an edge misses the box",
+			p0:     [2]float64{0, 25}, // above MaxY=20, moving horizontally only
+			d:      [2]float64{30, 0},
+			wantOK: false,
+		},
+		{
+			name:   "segment falls short of the box",
+			p0:     [2]float64{0, 15},
+			d:      [2]float64{5, 0},
+			wantOK: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tEnter, ok := segmentAABBEntry(c.p0, c.d, box)
+			if ok != c.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, c.wantOK)
+			}
+			if ok && math.Abs(tEnter-c.wantTEnter) > 1e-9 {
+				t.Errorf("tEnter = %v, want %v", tEnter, c.wantTEnter)
+			}
+		})
+	}
+}
+
+func TestSweepHitPicksEarliestTarget(t *testing.T) {
+	w := NewWorld(1.0)
+	far := w.AddTarget(Bounds{MinX: 40, MinY: 0, MaxX: 50, MaxY: 10}, nil)
+	near := w.AddTarget(Bounds{MinX: 10, MinY: 0, MaxX: 20, MaxY: 10}, nil)
+
+	hitID, t0, pos := w.sweepHit([2]float64{0, 5}, [2]float64{60, 5})
+	if hitID != near {
+		t.Fatalf("hitID = %d, want the nearer target %d (far = %d)", hitID, near, far)
+	}
+	wantT := 10.0 / 60.0
+	if math.Abs(t0-wantT) > 1e-9 {
+		t.Errorf("t = %v, want %v", t0, wantT)
+	}
+	if math.Abs(pos[0]-10) > 1e-9 {
+		t.Errorf("pos[0] = %v, want 10", pos[0])
+	}
+}
+
+func TestSweepHitNoTargets(t *testing.T) {
+	w := NewWorld(1.0)
+	hitID, _, _ := w.sweepHit([2]float64{0, 0}, [2]float64{10, 10})
+	if hitID != 0 {
+		t.Errorf("hitID = %d, want 0 (no targets)", hitID)
+	}
+}
+
+// TestStepUsesConfiguredIntegrator checks that Step advances a body with
+// whatever w.Integrator is set to, rather than hard-coding
+// trajectory.UpdateRK4 - e.g. a caller swapping in an adaptive stepper like
+// trajectory.IntegrateAdaptive for a large, speedFactor-scaled dt.
+func TestStepUsesConfiguredIntegrator(t *testing.T) {
+	w := NewWorld(1.0)
+	var gotDt float64
+	w.Integrator = func(p trajectory.TrajectoryPoint, dt float64) trajectory.TrajectoryPoint {
+		gotDt = dt
+		p.Position[0] += 1234.0 // an easily-recognizable, physically-nonsensical marker
+		return p
+	}
+	id := w.Spawn(trajectory.TrajectoryPoint{Position: [2]float64{0, 10}})
+
+	w.Step(0.5)
+
+	if gotDt != 0.5 {
+		t.Errorf("Integrator called with dt = %v, want 0.5", gotDt)
+	}
+	bodies := w.Bodies()
+	if len(bodies) != 1 || bodies[0].ID != id {
+		t.Fatalf("Bodies() = %+v, want the one spawned body", bodies)
+	}
+	if bodies[0].Trj.Position[0] != 1234.0 {
+		t.Errorf("Position[0] = %v, want 1234 (Step did not use the configured Integrator)", bodies[0].Trj.Position[0])
+	}
+}
+
+// TestProject3D checks that a Body3D's downrange and crosswind components
+// both land on the horizontal axis Targets are placed in, matching how a
+// wind-carrying shot is drawn on screen (see cbgui.go).
+func TestProject3D(t *testing.T) {
+	got := project3D([3]float64{10, 5, 2})
+	want := [2]float64{12, 5}
+	if got != want {
+		t.Errorf("project3D({10, 5, 2}) = %v, want %v", got, want)
+	}
+}
+
+// TestSpawn3DTracksBody checks that a wind-aware body spawned via Spawn3D
+// is findable through Bodies3D, the same as Spawn/Bodies for a still-air
+// Body.
+func TestSpawn3DTracksBody(t *testing.T) {
+	w := NewWorld(1.0)
+	id := w.Spawn3D(trajectory.TrajectoryPoint3D{Position: [3]float64{0, 5, 0}}, nil)
+
+	bodies := w.Bodies3D()
+	if len(bodies) != 1 || bodies[0].ID != id {
+		t.Fatalf("Bodies3D() = %+v, want the one spawned body with ID %d", bodies, id)
+	}
+}